@@ -30,29 +30,119 @@ func (t at) valid() bool {
 
 // AccessTokenManager manages the caching and renewal of the OAuth2 access token.
 type AccessTokenManager struct {
-	doer Doer
-	tm   TokenManager[CANameData]
-	mu   sync.Mutex
-	at   at
-	due  func(time.Time, time.Duration) bool
+	doer    Doer
+	tm      TokenManager[CANameData]
+	mu      sync.Mutex
+	at      at
+	due     func(time.Time, time.Duration) bool
+	axmName string
+	store   storage.AccessTokenRefresher
+	caOpts  []ClientAssertionTokenManagerOption
+
+	tokenURL string
+	hooks    Hooks
+	skew     *ClockSkew
+
+	// cooldown is how long invalid credentials are considered unhealthy.
+	cooldown  time.Duration
+	unhealthy *CredentialsError
+}
+
+// AccessTokenManagerOption configures an AccessTokenManager.
+type AccessTokenManagerOption func(*AccessTokenManager)
+
+// WithAccessTokenStore shares access tokens using store.
+// This allows multiple processes to use the same access token
+// rather than each requesting their own.
+// By default access tokens are only cached in memory.
+func WithAccessTokenStore(store storage.AccessTokenRefresher) AccessTokenManagerOption {
+	return func(m *AccessTokenManager) {
+		m.store = store
+	}
+}
+
+// WithClientAssertionOptions configures the client assertion token manager with opts.
+// For example [WithSigner] to sign client assertions with a different key source.
+func WithClientAssertionOptions(opts ...ClientAssertionTokenManagerOption) AccessTokenManagerOption {
+	return func(m *AccessTokenManager) {
+		m.caOpts = append(m.caOpts, opts...)
+	}
+}
+
+// WithTokenURL configures the OAuth 2 token URL access tokens are requested from.
+// See also [WithAudience] for the client assertion audience.
+// By default [Audience] is used.
+func WithTokenURL(tokenURL string) AccessTokenManagerOption {
+	return func(m *AccessTokenManager) {
+		m.tokenURL = tokenURL
+	}
+}
+
+// WithHooks configures hooks for access token and client assertion refresh events.
+// See [TokenEvent] for distinguishing between the two.
+func WithHooks(hooks Hooks) AccessTokenManagerOption {
+	return func(m *AccessTokenManager) {
+		m.hooks = hooks
+		m.caOpts = append(m.caOpts, WithClientAssertionHooks(hooks))
+	}
+}
+
+// WithClockSkew tracks the clock skew from token responses in skew and
+// adjusts token expiry calculations for it. Stored token expiries are
+// then according to the (estimated) server time which keeps them
+// consistent between hosts sharing storage.
+// Also configures the client assertion token manager.
+func WithClockSkew(skew *ClockSkew) AccessTokenManagerOption {
+	return func(m *AccessTokenManager) {
+		m.skew = skew
+		m.caOpts = append(m.caOpts, WithClientAssertionClockSkew(skew))
+	}
+}
+
+// WithUnhealthyCooldown configures how long an AxM name is considered
+// unhealthy after Apple rejects its credentials (i.e. an OAuth 2
+// "invalid_client" or "invalid_grant" error) even with a newly issued
+// client assertion. During this time no access
+// tokens are requested and a [CredentialsError] is returned instead.
+// Replacing the credentials should create a new manager (for example
+// see [Transport.Invalidate]) which clears this.
+// Zero or less disables this. By default [DefaultUnhealthyCooldown] is used.
+func WithUnhealthyCooldown(cooldown time.Duration) AccessTokenManagerOption {
+	return func(m *AccessTokenManager) {
+		m.cooldown = cooldown
+	}
 }
 
 // NewAccessTokenManager creates a new access token token manager.
 // Panics if doer is nil.
 // Tries to refresh the access token about 5 minutes before expiry (80% of validity).
-func NewAccessTokenManager(doer Doer, axmName string, store storage.ClientAssertionRefresher, jtiFn func() string) *AccessTokenManager {
+func NewAccessTokenManager(doer Doer, axmName string, store storage.ClientAssertionRefresher, jtiFn func() string, opts ...AccessTokenManagerOption) *AccessTokenManager {
 	if doer == nil {
 		panic("nil doer")
 	}
 
-	return &AccessTokenManager{
-		doer: doer,
-		tm:   NewClientAssertionTokenManager(axmName, store, jtiFn),
+	m := &AccessTokenManager{
+		doer:     doer,
+		axmName:  axmName,
+		cooldown: DefaultUnhealthyCooldown,
+	}
+
+	// at an Apple documented expiry of an hour, 0.8 makes the
+	// refresh 288 seconds or 4.8 minutes before expiry.
+	m.due = newPctDueAt(m.now, 0.8)
 
-		// at an Apple documented expiry of an hour, 0.8 makes the
-		// refresh 288 seconds or 4.8 minutes before expiry.
-		due: newPctDue(0.8),
+	for _, opt := range opts {
+		opt(m)
 	}
+
+	m.tm = NewClientAssertionTokenManager(axmName, store, jtiFn, m.caOpts...)
+
+	return m
+}
+
+// now returns the current (skew-adjusted) time.
+func (m *AccessTokenManager) now() time.Time {
+	return m.skew.Now()
 }
 
 // GetOrRefreshToken retrieves (or refreshes) the OAuth2 access token.
@@ -65,28 +155,179 @@ func (m *AccessTokenManager) GetOrRefreshToken(ctx context.Context, forceRefresh
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if err := m.unhealthyErr(); err != nil {
+		return "", err
+	}
+
 	if !forceRefresh && m.at.valid() && !m.due(m.at.expiry, m.at.validity) {
 		// return the currently cached token
 		return m.at.token, nil
 	}
 
-	ca, err := m.tm.GetOrRefreshToken(ctx, forceRefresh)
+	return m.refreshHealthy(ctx, forceRefresh, 0)
+}
+
+// unhealthyErr returns the credentials error if the credentials
+// are still considered unhealthy.
+// The access token lock must be held.
+func (m *AccessTokenManager) unhealthyErr() error {
+	if m.unhealthy == nil {
+		return nil
+	}
+	if time.Now().Before(m.unhealthy.Until) {
+		return m.unhealthy
+	}
+	// cool-down is over: try the credentials again
+	m.unhealthy = nil
+	return nil
+}
+
+// refreshHealthy refreshes the access token like refresh but marks
+// the credentials unhealthy if Apple rejects them.
+// Rejected refreshes are tried again once with a newly issued client
+// assertion if that may help (see retryable).
+// The access token lock must be held.
+func (m *AccessTokenManager) refreshHealthy(ctx context.Context, force bool, ahead time.Duration) (string, error) {
+	token, err := m.refresh(ctx, force, ahead)
+	if err != nil && !force && m.retryable(err) {
+		// try again once with a newly issued client assertion
+		token, err = m.refresh(ctx, true, ahead)
+	}
+	if err == nil || m.cooldown <= 0 || !invalidCredentials(err) {
+		return token, err
+	}
+
+	// avoid using a cached token issued for the rejected credentials
+	m.at = at{}
+	m.unhealthy = &CredentialsError{
+		AxMName: m.axmName,
+		Until:   time.Now().Add(m.cooldown),
+		Err:     err,
+	}
+	return "", m.unhealthy
+}
+
+// retryable returns true if err from an access token refresh may
+// succeed with a newly issued client assertion.
+func (m *AccessTokenManager) retryable(err error) bool {
+	var er *ErrorResponse
+	if !errors.As(err, &er) {
+		return false
+	}
+	if invalidCredentials(err) {
+		// the client assertion itself may have been rejected,
+		// e.g. it was signed by a since revoked key.
+		return true
+	}
+	// the client assertion may have been issued for a different
+	// clock skew (e.g. before any skew was observed).
+	sr, ok := m.tm.(skewedRefresher)
+	return ok && sr.skewed()
+}
+
+// aheadRefresher refreshes tokens that become due within ahead.
+type aheadRefresher interface {
+	RefreshAhead(ctx context.Context, ahead time.Duration) error
+}
+
+// RefreshAhead refreshes the client assertion and access token if
+// they become due within ahead.
+// This allows refreshing before the access token is needed.
+func (m *AccessTokenManager) RefreshAhead(ctx context.Context, ahead time.Duration) error {
+	if m.due == nil {
+		return ErrDueNil
+	}
+
+	if r, ok := m.tm.(aheadRefresher); ok {
+		if err := r.RefreshAhead(ctx, ahead); err != nil {
+			return fmt.Errorf("refreshing client assertion: %w", err)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.unhealthyErr(); err != nil {
+		return err
+	}
+
+	if m.at.valid() && !m.due(m.at.expiry.Add(-ahead), m.at.validity) {
+		return nil
+	}
+
+	_, err := m.refreshHealthy(ctx, false, ahead)
+	return err
+}
+
+// refresh refreshes the access token (and client assertion if force is set).
+// A stored access token is only used if it does not become due within ahead.
+// The access token lock must be held.
+func (m *AccessTokenManager) refresh(ctx context.Context, force bool, ahead time.Duration) (string, error) {
+	// get the client assertion before any access token storage
+	// access to avoid e.g. nested database row locking.
+	ca, err := m.tm.GetOrRefreshToken(ctx, force)
 	if err != nil {
 		return "", fmt.Errorf("getting client assertion: %w", err)
 	}
 
 	// retrieve a new access token
-	tr, err := DoGetToken(ctx, m.doer, ca.ClientID, ca.ClientAssertion)
-	if err != nil {
-		return "", fmt.Errorf("fetching access token: %w", err)
+	refreshFunc := func(ctx context.Context) (token storage.AccessToken, err error) {
+		m.hooks.start(ctx, TokenKindAccessToken, m.axmName)
+		defer func() {
+			m.hooks.done(ctx, TokenKindAccessToken, m.axmName, token.Expiry, m.skew.Skew(), err)
+		}()
+
+		if m.tokenURL != "" {
+			ctx = WithGetTokenURL(ctx, m.tokenURL)
+		}
+		if ca.Scope != "" {
+			ctx = WithGetTokenScope(ctx, ca.Scope)
+		}
+
+		var doer Doer = m.doer
+		if m.skew != nil {
+			doer = skewDoer{doer: doer, skew: m.skew}
+		}
+
+		// the token expires relative to when it was issued so
+		// conservatively use the time before the request.
+		start := m.now()
+		tr, err := DoGetToken(ctx, doer, ca.ClientID, ca.ClientAssertion)
+		if err != nil {
+			return token, err
+		}
+
+		expiresIn := time.Duration(tr.ExpiresIn) * time.Second
+
+		return storage.AccessToken{
+			Token:    tr.AccessToken,
+			Validity: expiresIn,
+			Expiry:   start.Add(expiresIn),
+		}, nil
 	}
 
-	expiresIn := time.Duration(tr.ExpiresIn) * time.Second
+	var token storage.AccessToken
+	if m.store == nil {
+		token, err = refreshFunc(ctx)
+		if err != nil {
+			return "", fmt.Errorf("fetching access token: %w", err)
+		}
+	} else {
+		// the storage checks if the stored access token is due while
+		// it holds its lock so simultaneous refreshes only happen once.
+		due := func(token storage.AccessToken) bool {
+			return force || m.due(token.Expiry.Add(-ahead), token.Validity)
+		}
+		token, err = m.store.GetOrRefreshAccessToken(ctx, m.axmName, refreshFunc, due)
+		if err != nil {
+			return "", fmt.Errorf("getting stored access token: %w", err)
+		}
+	}
 
 	m.at = at{
-		token:    tr.AccessToken,
-		validity: expiresIn,
-		expiry:   time.Now().Add(expiresIn),
+		token:    token.Token,
+		validity: token.Validity,
+		expiry:   token.Expiry,
 	}
 
 	return m.at.token, nil
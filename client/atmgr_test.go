@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/micromdm/nanoaxm/storage"
+	"github.com/micromdm/nanoaxm/storage/inmem"
+)
+
+// tokenDoer responds to access token requests with a new token each call.
+type tokenDoer struct {
+	calls int
+}
+
+func (d *tokenDoer) Do(*http.Request) (*http.Response, error) {
+	d.calls++
+	body := fmt.Sprintf(`{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, d.calls)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+// newTestStore creates an in-memory store with auth credentials for axmName.
+func newTestStore(t *testing.T, axmName string) *inmem.InMem {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := inmem.New()
+	err = store.StoreAuthCredentials(context.Background(), axmName, storage.AuthCredentials{
+		ClientID:      "BUSINESSAPI.test-client-id",
+		KeyID:         "test-key-id",
+		PrivateKeyPEM: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestAccessTokenManagerStore(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "test")
+	doer := &tokenDoer{}
+
+	// two managers (think: replicas) sharing the same storage
+	m1 := NewAccessTokenManager(doer, "test", store, uuid.NewString, WithAccessTokenStore(store))
+	m2 := NewAccessTokenManager(doer, "test", store, uuid.NewString, WithAccessTokenStore(store))
+
+	tok1, err := m1.GetOrRefreshToken(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tok2, err := m2.GetOrRefreshToken(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tok1 != tok2 {
+		t.Errorf("tokens differ: %s, %s", tok1, tok2)
+	}
+	if have, want := doer.calls, 1; have != want {
+		t.Errorf("token requests: have: %d, want: %d", have, want)
+	}
+
+	// forcing a refresh should request a new token
+	tok2, err = m2.GetOrRefreshToken(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok1 == tok2 {
+		t.Error("expected new token")
+	}
+	if have, want := doer.calls, 2; have != want {
+		t.Errorf("token requests: have: %d, want: %d", have, want)
+	}
+}
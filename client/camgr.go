@@ -3,12 +3,17 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/micromdm/nanoaxm/storage"
 )
 
+// skewResolution is the resolution of clock skew measurements.
+// Smaller skew changes are not meaningful.
+const skewResolution = time.Second
+
 // CANameData is the Client Assertion and AxM name data.
 // This structure is the "token" data managed by the Client Assertion token manager.
 type CANameData struct {
@@ -17,6 +22,10 @@ type CANameData struct {
 	// ClientID can be used to determine the OAuth2 scope.
 	// I.e. Apple Business Manager or Apple School Manager.
 	ClientID string
+
+	// Scope is the explicit OAuth2 scope, if any.
+	// If empty the scope should be determined from ClientID.
+	Scope string
 }
 
 type ClientAssertionTokenManager struct {
@@ -26,12 +35,65 @@ type ClientAssertionTokenManager struct {
 	clientAssertionMu sync.Mutex
 	due               func(time.Time, time.Duration) bool
 	jtiFn             func() string
+	signerFn          SignerSelector
+	audience          string
+	hooks             Hooks
+	skew              *ClockSkew
+	backdate          time.Duration
+
+	// caSkew is the clock skew when the cached client assertion was
+	// issued (or retrieved from storage).
+	caSkew time.Duration
+}
+
+// ClientAssertionTokenManagerOption configures a ClientAssertionTokenManager.
+type ClientAssertionTokenManagerOption func(*ClientAssertionTokenManager)
+
+// WithSigner configures the selection of the signer for client assertions.
+// By default the private key PEM of the auth credentials is used (see [PEMSigner]).
+func WithSigner(signerFn SignerSelector) ClientAssertionTokenManagerOption {
+	return func(m *ClientAssertionTokenManager) {
+		m.signerFn = signerFn
+	}
+}
+
+// WithAudience configures the audience of new client assertions.
+// Existing stored client assertions are used until they are refreshed.
+// By default [Audience] is used.
+func WithAudience(audience string) ClientAssertionTokenManagerOption {
+	return func(m *ClientAssertionTokenManager) {
+		m.audience = audience
+	}
+}
+
+// WithClientAssertionHooks configures hooks for client assertion refresh events.
+func WithClientAssertionHooks(hooks Hooks) ClientAssertionTokenManagerOption {
+	return func(m *ClientAssertionTokenManager) {
+		m.hooks = hooks
+	}
+}
+
+// WithClientAssertionClockSkew adjusts client assertion times for the clock skew tracked by skew.
+// Client assertions are issued and expire according to the (estimated) server time.
+func WithClientAssertionClockSkew(skew *ClockSkew) ClientAssertionTokenManagerOption {
+	return func(m *ClientAssertionTokenManager) {
+		m.skew = skew
+	}
+}
+
+// WithIssuedAtBackdate backdates the issued at ("iat") time of new
+// client assertions by d to tolerate servers with clocks behind ours.
+// The expiry is relative to the issued at time so the validity is unchanged.
+func WithIssuedAtBackdate(d time.Duration) ClientAssertionTokenManagerOption {
+	return func(m *ClientAssertionTokenManager) {
+		m.backdate = d
+	}
 }
 
 // NewClientAssertionTokenManager creates a new client assertion token manager.
 // Panics if axmName or store are nil.
 // Tries to refresh the client assertion about 9 days before expiry (95% of validity).
-func NewClientAssertionTokenManager(axmName string, store storage.ClientAssertionRefresher, jtiFn func() string) *ClientAssertionTokenManager {
+func NewClientAssertionTokenManager(axmName string, store storage.ClientAssertionRefresher, jtiFn func() string, opts ...ClientAssertionTokenManagerOption) *ClientAssertionTokenManager {
 	if axmName == "" {
 		panic("nil AxM name")
 	}
@@ -42,28 +104,49 @@ func NewClientAssertionTokenManager(axmName string, store storage.ClientAssertio
 		panic("nil JTI func")
 	}
 
-	return &ClientAssertionTokenManager{
-		axmName: axmName,
-		store:   store,
-		jtiFn:   jtiFn,
+	m := &ClientAssertionTokenManager{
+		axmName:  axmName,
+		store:    store,
+		jtiFn:    jtiFn,
+		signerFn: PEMSigner,
+		audience: Audience,
+	}
+
+	// at a Apple documented expiry of 180 days, 0.95 makes the
+	// refresh 171 days or 9 days before expiry.
+	m.due = newPctDueAt(m.now, 0.95)
 
-		// at a Apple documented expiry of 180 days, 0.95 makes the
-		// refresh 171 days or 9 days before expiry.
-		due: newPctDue(0.95),
+	for _, opt := range opts {
+		opt(m)
 	}
+
+	return m
+}
+
+// now returns the current (skew-adjusted) time.
+func (m *ClientAssertionTokenManager) now() time.Time {
+	return m.skew.Now()
 }
 
-// clientAssertionRefreshFunc generates a new Client Assertion
-func clientAssertionRefreshFunc(jtiFn func() string) func(ctx context.Context, ac storage.AuthCredentials) (ca storage.ClientAssertion, err error) {
-	return func(ctx context.Context, ac storage.AuthCredentials) (ca storage.ClientAssertion, err error) {
-		now := time.Now()
-		ca.Validity = ClientAssertionDaysExpiry * 24 * time.Hour
-		ca.Expiry = now.Add(ca.Validity)
-		ca.JTI = jtiFn()
-		ca.ClientID = ac.ClientID
-		ca.Token, err = NewClientAssertion(ac, Audience, ca.JTI, now, ca.Expiry)
-		return
+// refreshClientAssertion generates a new Client Assertion.
+func (m *ClientAssertionTokenManager) refreshClientAssertion(ctx context.Context, ac storage.AuthCredentials) (ca storage.ClientAssertion, err error) {
+	m.hooks.start(ctx, TokenKindClientAssertion, m.axmName)
+	defer func() {
+		m.hooks.done(ctx, TokenKindClientAssertion, m.axmName, ca.Expiry, m.skew.Skew(), err)
+	}()
+
+	signer, err := m.signerFn(ctx, m.axmName, ac)
+	if err != nil {
+		return ca, fmt.Errorf("selecting signer: %w", err)
 	}
+	iat := m.now().Add(-m.backdate)
+	ca.Validity = ClientAssertionDaysExpiry * 24 * time.Hour
+	ca.Expiry = iat.Add(ca.Validity)
+	ca.JTI = m.jtiFn()
+	ca.ClientID = ac.ClientID
+	ca.Scope = ac.Scope
+	ca.Token, err = NewClientAssertionWithSigner(ac, signer, m.audience, ca.JTI, iat, ca.Expiry)
+	return
 }
 
 // GetOrRefreshToken retrieves (or refreshes) the OAuth2 client assertion token.
@@ -83,22 +166,90 @@ func (m *ClientAssertionTokenManager) GetOrRefreshToken(ctx context.Context, for
 		return CANameData{
 			ClientAssertion: m.clientAssertion.Token,
 			ClientID:        m.clientAssertion.ClientID,
+			Scope:           m.clientAssertion.Scope,
 		}, nil
 	}
 
-	ca, err := m.store.GetOrRefreshClientAssertion(ctx, m.axmName, clientAssertionRefreshFunc(m.jtiFn), forceRefresh)
-	caNameData := CANameData{
+	ca, err := m.getOrRefresh(ctx, forceRefresh)
+	return CANameData{
 		ClientAssertion: ca.Token,
 		ClientID:        ca.ClientID,
-	}
+		Scope:           ca.Scope,
+	}, err
+}
+
+// getOrRefresh retrieves (or refreshes) the client assertion from the
+// store and caches it. The client assertion lock must be held.
+func (m *ClientAssertionTokenManager) getOrRefresh(ctx context.Context, refresh bool) (storage.ClientAssertion, error) {
+	ca, err := m.store.GetOrRefreshClientAssertion(ctx, m.axmName, m.refreshClientAssertion, refresh)
 	if err != nil {
-		return caNameData, err
+		return ca, err
 	}
 	if err = ca.ValidError(); err != nil {
-		return caNameData, err
+		return ca, err
 	}
 
 	m.clientAssertion = ca
+	m.caSkew = m.skew.Skew()
+
+	return ca, nil
+}
+
+// skewChanged returns true if the clock skew changed by more than d
+// since the cached client assertion was issued.
+// The client assertion lock must be held.
+func (m *ClientAssertionTokenManager) skewChanged(d time.Duration) bool {
+	if !m.skew.Observed() || !m.clientAssertion.Valid() {
+		return false
+	}
+	change := m.skew.Skew() - m.caSkew
+	return change > d || change < -d
+}
+
+// skewedRefresher refreshes tokens if they may be rejected because of clock skew.
+type skewedRefresher interface {
+	skewed() bool
+}
+
+// skewed returns true if the clock skew changed by more than the
+// issued at backdate since the cached client assertion was issued.
+// The server may then consider the client assertion issued in the
+// future and reject it: it should be issued again.
+func (m *ClientAssertionTokenManager) skewed() bool {
+	m.clientAssertionMu.Lock()
+	defer m.clientAssertionMu.Unlock()
+
+	d := m.backdate
+	if d < skewResolution {
+		d = skewResolution
+	}
+	return m.skewChanged(d)
+}
+
+// RefreshAhead refreshes the client assertion if it becomes due within ahead.
+// This allows refreshing before the client assertion is needed.
+func (m *ClientAssertionTokenManager) RefreshAhead(ctx context.Context, ahead time.Duration) error {
+	if m.due == nil {
+		return ErrDueNil
+	}
+	if m.store == nil {
+		return errors.New("nil store")
+	}
+
+	m.clientAssertionMu.Lock()
+	defer m.clientAssertionMu.Unlock()
+
+	if !m.clientAssertion.Valid() {
+		// nothing cached yet: load from storage first
+		if _, err := m.getOrRefresh(ctx, false); err != nil {
+			return err
+		}
+	}
+
+	if !m.due(m.clientAssertion.Expiry.Add(-ahead), m.clientAssertion.Validity) {
+		return nil
+	}
 
-	return caNameData, nil
+	_, err := m.getOrRefresh(ctx, true)
+	return err
 }
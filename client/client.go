@@ -73,11 +73,6 @@ func pctDue(now time.Time, expiry time.Time, validity time.Duration, pct float64
 	return expiry.Sub(now) < time.Duration(float64(validity)*rPct)
 }
 
-// newPctDue returns a function that calculates whether a given expiry time is within a certain percentage of its validity period.
-func newPctDue(pct float64) func(expiry time.Time, validity time.Duration) bool {
-	return newPctDueAt(time.Now, pct)
-}
-
 // newPctDueAt returns a function that calculates whether a given expiry time is within a certain percentage of its validity period, using the provided now function.
 func newPctDueAt(nowFn func() time.Time, pct float64) func(expiry time.Time, validity time.Duration) bool {
 	return func(expiry time.Time, validity time.Duration) bool {
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultUnhealthyCooldown is the default time AxM names with invalid
+// credentials are considered unhealthy before trying them again.
+const DefaultUnhealthyCooldown = 5 * time.Minute
+
+// ErrCredentialsUnhealthy is returned (wrapped in a [CredentialsError])
+// when access tokens are not requested because of invalid credentials.
+var ErrCredentialsUnhealthy = errors.New("credentials unhealthy")
+
+// CredentialsError indicates the credentials of an AxM name were
+// rejected by Apple, e.g. because the API key was revoked.
+// It matches [ErrCredentialsUnhealthy] with [errors.Is].
+type CredentialsError struct {
+	AxMName string
+
+	// Until is when the AxM name will be tried again.
+	Until time.Time
+
+	// Err is the error that made the credentials unhealthy.
+	// Usually wraps the OAuth 2 [ErrorResponse].
+	Err error
+}
+
+// Error returns the credentials error as an error string.
+func (e *CredentialsError) Error() string {
+	return fmt.Sprintf("%s: %s until %s: %v", ErrCredentialsUnhealthy, e.AxMName, e.Until.Format(time.RFC3339), e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CredentialsError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if target is [ErrCredentialsUnhealthy].
+func (e *CredentialsError) Is(target error) bool {
+	return target == ErrCredentialsUnhealthy
+}
+
+// invalidCredentials returns true if err is an OAuth 2 error response
+// indicating the client (or its client assertion) is invalid.
+func invalidCredentials(err error) bool {
+	var er *ErrorResponse
+	if !errors.As(err, &er) {
+		return false
+	}
+	return er.ErrorString == "invalid_client" || er.ErrorString == "invalid_grant"
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// countDoer counts requests to doer.
+type countDoer struct {
+	doer  Doer
+	calls int
+}
+
+func (d *countDoer) Do(req *http.Request) (*http.Response, error) {
+	d.calls++
+	return d.doer.Do(req)
+}
+
+func TestUnhealthyCredentials(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "test")
+
+	d := &countDoer{doer: errorDoer{}}
+	m := NewAccessTokenManager(d, "test", store, uuid.NewString, WithUnhealthyCooldown(time.Hour))
+
+	_, err := m.GetOrRefreshToken(ctx, false)
+	if !errors.Is(err, ErrCredentialsUnhealthy) {
+		t.Fatalf("expected unhealthy credentials error, got: %v", err)
+	}
+	var er *ErrorResponse
+	if !errors.As(err, &er) || er.ErrorString != "invalid_client" {
+		t.Errorf("expected invalid_client error response, got: %v", err)
+	}
+
+	// negative cached: no more requests to Apple, even when forced
+	// (the rejected request was tried again with a new client assertion)
+	_, err = m.GetOrRefreshToken(ctx, true)
+	if !errors.Is(err, ErrCredentialsUnhealthy) {
+		t.Errorf("expected unhealthy credentials error, got: %v", err)
+	}
+	if err = m.RefreshAhead(ctx, time.Minute); !errors.Is(err, ErrCredentialsUnhealthy) {
+		t.Errorf("expected unhealthy credentials error, got: %v", err)
+	}
+	if have, want := d.calls, 2; have != want {
+		t.Errorf("calls: have: %d, want: %d", have, want)
+	}
+
+	// once the cool-down is over the credentials are tried again
+	m.unhealthy.Until = time.Now()
+	d.doer = &tokenDoer{}
+	if _, err = m.GetOrRefreshToken(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := d.calls, 3; have != want {
+		t.Errorf("calls: have: %d, want: %d", have, want)
+	}
+}
+
+func TestUnhealthyCredentialsDisabled(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "test")
+
+	d := &countDoer{doer: errorDoer{}}
+	m := NewAccessTokenManager(d, "test", store, uuid.NewString, WithUnhealthyCooldown(0))
+
+	for i := 0; i < 2; i++ {
+		_, err := m.GetOrRefreshToken(ctx, false)
+		if err == nil || errors.Is(err, ErrCredentialsUnhealthy) {
+			t.Errorf("expected plain error, got: %v", err)
+		}
+	}
+	if have, want := d.calls, 4; have != want {
+		t.Errorf("calls: have: %d, want: %d", have, want)
+	}
+}
+
+// rejectFirstDoer rejects the first client assertion it sees and
+// accepts any others.
+type rejectFirstDoer struct {
+	first string
+	calls int
+}
+
+func (d *rejectFirstDoer) Do(req *http.Request) (*http.Response, error) {
+	d.calls++
+	if err := req.ParseForm(); err != nil {
+		return nil, err
+	}
+	ca := req.PostForm.Get("client_assertion")
+	if d.first == "" {
+		d.first = ca
+	}
+	if ca == d.first {
+		return errorDoer{}.Do(req)
+	}
+	return (&tokenDoer{}).Do(req)
+}
+
+func TestRejectedClientAssertion(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "test")
+
+	d := &rejectFirstDoer{}
+	m := NewAccessTokenManager(d, "test", store, uuid.NewString, WithUnhealthyCooldown(time.Hour))
+
+	if _, err := m.GetOrRefreshToken(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := d.calls, 2; have != want {
+		t.Errorf("calls: have: %d, want: %d", have, want)
+	}
+	if m.unhealthy != nil {
+		t.Errorf("expected healthy credentials: %v", m.unhealthy)
+	}
+
+	// the newly issued client assertion should have been stored
+	ca, err := store.RetrieveClientAssertion(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ca.Token == d.first {
+		t.Error("expected the rejected client assertion to be replaced")
+	}
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TokenKind is the kind of token in a [TokenEvent].
+type TokenKind string
+
+const (
+	// TokenKindClientAssertion is the OAuth 2 client assertion (JWT).
+	TokenKindClientAssertion TokenKind = "client_assertion"
+
+	// TokenKindAccessToken is the OAuth 2 access token.
+	TokenKindAccessToken TokenKind = "access_token"
+)
+
+// TokenEvent describes a token refresh.
+type TokenEvent struct {
+	Kind    TokenKind
+	AxMName string
+
+	// Expiry of the new token. Only set for successful refreshes.
+	Expiry time.Time
+
+	// Err is the refresh error. Only set for failed refreshes.
+	Err error
+
+	// ErrorResponse is the OAuth 2 error response, if any.
+	// Only set for failed access token refreshes.
+	ErrorResponse *ErrorResponse
+
+	// ClockSkew is the clock skew observed at the time of the refresh, if any.
+	// Positive if the server clock is ahead of ours. See [ClockSkew].
+	ClockSkew time.Duration
+}
+
+// Hooks are callbacks for token lifecycle events.
+// For example to feed metrics or alerting.
+// Any of the callbacks may be nil.
+// Callbacks are called synchronously so should return quickly.
+type Hooks struct {
+	// OnRefreshStart is called before a token is refreshed.
+	OnRefreshStart func(ctx context.Context, ev TokenEvent)
+
+	// OnRefreshSuccess is called after a token is successfully refreshed.
+	OnRefreshSuccess func(ctx context.Context, ev TokenEvent)
+
+	// OnRefreshFailure is called after a token refresh fails.
+	OnRefreshFailure func(ctx context.Context, ev TokenEvent)
+}
+
+// start calls the refresh start hook for kind and axmName.
+func (h Hooks) start(ctx context.Context, kind TokenKind, axmName string) {
+	if h.OnRefreshStart != nil {
+		h.OnRefreshStart(ctx, TokenEvent{Kind: kind, AxMName: axmName})
+	}
+}
+
+// done calls either the refresh success or failure hook depending on err.
+func (h Hooks) done(ctx context.Context, kind TokenKind, axmName string, expiry time.Time, skew time.Duration, err error) {
+	ev := TokenEvent{Kind: kind, AxMName: axmName, ClockSkew: skew}
+	if err != nil {
+		if h.OnRefreshFailure != nil {
+			ev.Err = err
+			errors.As(err, &ev.ErrorResponse)
+			h.OnRefreshFailure(ctx, ev)
+		}
+		return
+	}
+	if h.OnRefreshSuccess != nil {
+		ev.Expiry = expiry
+		h.OnRefreshSuccess(ctx, ev)
+	}
+}
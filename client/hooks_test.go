@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// errorDoer responds to access token requests with an OAuth 2 error.
+type errorDoer struct{}
+
+func (errorDoer) Do(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       io.NopCloser(strings.NewReader(`{"error":"invalid_client"}`)),
+	}, nil
+}
+
+func TestHooks(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "test")
+
+	var events []string
+	var failure TokenEvent
+	hooks := Hooks{
+		OnRefreshStart: func(_ context.Context, ev TokenEvent) {
+			events = append(events, "start "+string(ev.Kind))
+		},
+		OnRefreshSuccess: func(_ context.Context, ev TokenEvent) {
+			if ev.Expiry.IsZero() {
+				t.Errorf("%s: zero expiry", ev.Kind)
+			}
+			events = append(events, "success "+string(ev.Kind))
+		},
+		OnRefreshFailure: func(_ context.Context, ev TokenEvent) {
+			failure = ev
+			events = append(events, "failure "+string(ev.Kind))
+		},
+	}
+
+	m := NewAccessTokenManager(&tokenDoer{}, "test", store, uuid.NewString, WithHooks(hooks))
+	if _, err := m.GetOrRefreshToken(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "start client_assertion,success client_assertion,start access_token,success access_token"
+	if have := strings.Join(events, ","); have != want {
+		t.Errorf("events: have: %s, want: %s", have, want)
+	}
+
+	// the client assertion is now stored so only the access token is
+	// refreshed. it is rejected so a new client assertion is tried once.
+	events = nil
+	m = NewAccessTokenManager(errorDoer{}, "test", store, uuid.NewString, WithHooks(hooks))
+	if _, err := m.GetOrRefreshToken(ctx, false); err == nil {
+		t.Fatal("expected error")
+	}
+
+	want = "start access_token,failure access_token,start client_assertion,success client_assertion,start access_token,failure access_token"
+	if have := strings.Join(events, ","); have != want {
+		t.Errorf("events: have: %s, want: %s", have, want)
+	}
+	if failure.ErrorResponse == nil || failure.ErrorResponse.ErrorString != "invalid_client" {
+		t.Errorf("expected invalid_client error response, got: %v", failure.ErrorResponse)
+	}
+	if have, want := failure.AxMName, "test"; have != want {
+		t.Errorf("AxM name: have: %s, want: %s", have, want)
+	}
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Limit configures rate and concurrency limits for an AxM name.
+// Requests exceeding the limits are queued until they can be sent
+// (or their context is done).
+// The zero value means no limits.
+type Limit struct {
+	// Rate is the sustained number of requests per second.
+	// Zero or less means no rate limit.
+	Rate float64
+
+	// Burst is the maximum number of requests that may be sent at
+	// once in excess of Rate. Values less than one are treated as one.
+	Burst int
+
+	// MaxInFlight is the maximum number of concurrent requests.
+	// A request is in-flight until its response body is closed.
+	// Zero or less means no concurrency limit.
+	MaxInFlight int
+}
+
+// enabled reports whether l limits anything.
+func (l Limit) enabled() bool {
+	return l.Rate > 0 || l.MaxInFlight > 0
+}
+
+// limiter enforces a Limit.
+type limiter struct {
+	// sem is a counting semaphore for in-flight requests.
+	sem chan struct{}
+
+	rate   float64
+	burst  float64
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(l Limit) *limiter {
+	lim := &limiter{rate: l.Rate}
+	if l.MaxInFlight > 0 {
+		lim.sem = make(chan struct{}, l.MaxInFlight)
+	}
+	if lim.rate > 0 {
+		lim.burst = float64(l.Burst)
+		if lim.burst < 1 {
+			lim.burst = 1
+		}
+		lim.tokens = lim.burst
+	}
+	return lim
+}
+
+// reserve takes a token from the bucket at now returning how long
+// to wait before the token may be used.
+func (l *limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// unreserve returns a token to the bucket.
+func (l *limiter) unreserve() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+}
+
+// wait blocks until a request may be sent or ctx is done.
+// If no error is returned then release must be called when the request is done.
+func (l *limiter) wait(ctx context.Context) (release func(), err error) {
+	release = func() {}
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+			release = func() { <-l.sem }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if l.rate > 0 {
+		if d := l.reserve(time.Now()); d > 0 {
+			if err = sleepContext(ctx, d); err != nil {
+				l.unreserve()
+				release()
+				return nil, err
+			}
+		}
+	}
+	return release, nil
+}
+
+// releaseBody calls release once when the body is closed.
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
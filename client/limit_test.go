@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLimiterReserve(t *testing.T) {
+	lim := newLimiter(Limit{Rate: 2, Burst: 2})
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, want := range []time.Duration{0, 0, 500 * time.Millisecond, time.Second} {
+		if have := lim.reserve(now); have != want {
+			t.Errorf("index %d: have: %v, want: %v", i, have, want)
+		}
+	}
+
+	// two seconds later the queued reservations are paid back
+	// and the bucket refilled by two tokens
+	if have, want := lim.reserve(now.Add(2*time.Second)), time.Duration(0); have != want {
+		t.Errorf("have: %v, want: %v", have, want)
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	lim := newLimiter(Limit{MaxInFlight: 1})
+	ctx := context.Background()
+
+	release, err := lim.wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// second request must queue until the first is released
+	cCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err = lim.wait(cCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got: %v", err)
+	}
+
+	release()
+
+	release, err = lim.wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
+
+func TestTransportLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	tr := newTestTransport(
+		WithLimit(Limit{MaxInFlight: 1}),
+		WithNameLimit("unlimited", Limit{}),
+	)
+
+	req, err := NewRequestWithContext(context.Background(), "test", http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first response body is still open, so this should queue
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err = tr.RoundTrip(req.WithContext(WithName(ctx, "test"))); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got: %v", err)
+	}
+
+	// other names are not affected
+	req2, _ := NewRequestWithContext(context.Background(), "unlimited", http.MethodGet, srv.URL, nil)
+	resp2, err := tr.RoundTrip(req2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp2.Body.Close()
+
+	resp.Body.Close()
+
+	resp, err = tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
@@ -12,17 +12,44 @@ import (
 
 	"github.com/micromdm/nanoaxm/cryptoutil"
 	"github.com/micromdm/nanoaxm/storage"
-
-	"github.com/golang-jwt/jwt/v5"
 )
 
 const (
+	// Audience is the default OAuth 2 token URL and client assertion audience.
 	Audience = "https://account.apple.com/auth/oauth2/v2/token"
 
 	ClientAssertionType       = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
 	ClientAssertionDaysExpiry = 180
+
+	// ScopeBusiness is the OAuth 2 scope for Apple Business Manager.
+	ScopeBusiness = "business.api"
+	// ScopeSchool is the OAuth 2 scope for Apple School Manager.
+	ScopeSchool = "school.api"
+
+	// businessClientIDPrefix is the prefix of Apple Business Manager client IDs.
+	businessClientIDPrefix = "BUSINESSAPI."
 )
 
+// ScopeForClientID determines the OAuth 2 scope from the prefix of clientID.
+// Apple Business Manager client IDs start with "BUSINESSAPI."
+// and all others are assumed to be Apple School Manager.
+func ScopeForClientID(clientID string) string {
+	if strings.HasPrefix(clientID, businessClientIDPrefix) {
+		return ScopeBusiness
+	}
+	return ScopeSchool
+}
+
+// ScopeForAuthCredentials returns the OAuth 2 scope for ac.
+// The explicit scope of ac is preferred. Otherwise the scope is
+// determined from the client ID (see [ScopeForClientID]).
+func ScopeForAuthCredentials(ac storage.AuthCredentials) string {
+	if ac.Scope != "" {
+		return ac.Scope
+	}
+	return ScopeForClientID(ac.ClientID)
+}
+
 // TokenResponse represents the OAuth 2 successful token response structure.
 // See https://datatracker.ietf.org/doc/html/rfc6749#section-5.1
 type TokenResponse struct {
@@ -86,28 +113,12 @@ func NewClientAssertion(ac storage.AuthCredentials, audience, jti string, now ti
 		return "", fmt.Errorf("new client assertion: auth creds invalid: %w", err)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
-		"sub": ac.ClientID,
-		"aud": audience,
-		"iat": now.Unix(),
-		"exp": expiry.Unix(),
-		"jti": jti,
-		"iss": ac.ClientID, // team ID
-	})
-
-	token.Header["kid"] = ac.KeyID
-
 	privKey, err := cryptoutil.ECPrivateKeyFromPEM(ac.PrivateKeyPEM)
 	if err != nil {
 		return "", fmt.Errorf("new client assertion: key from PEM: %w", err)
 	}
 
-	clientAssertion, err := token.SignedString(privKey)
-	if err != nil {
-		return "", fmt.Errorf("new client assertion: signing: %w", err)
-	}
-
-	return clientAssertion, nil
+	return NewClientAssertionWithSigner(ac, privKey, audience, jti, now, expiry)
 }
 
 // ctxKeyGetTokenUserAgent is the context key for the user agent string when requesting an access token.
@@ -119,9 +130,29 @@ func WithGetTokenUserAgent(ctx context.Context, userAgent string) context.Contex
 	return context.WithValue(ctx, ctxKeyGetTokenUserAgent{}, userAgent)
 }
 
+// ctxKeyGetTokenURL is the context key for the token URL when requesting an access token.
+type ctxKeyGetTokenURL struct{}
+
+// WithGetTokenURL creates a new context from ctx with the token URL associated.
+// Only useful for overiding the token URL in [DoGetToken].
+func WithGetTokenURL(ctx context.Context, tokenURL string) context.Context {
+	return context.WithValue(ctx, ctxKeyGetTokenURL{}, tokenURL)
+}
+
+// ctxKeyGetTokenScope is the context key for the OAuth 2 scope when requesting an access token.
+type ctxKeyGetTokenScope struct{}
+
+// WithGetTokenScope creates a new context from ctx with the OAuth 2 scope associated.
+// Only useful for overiding the scope in [DoGetToken].
+func WithGetTokenScope(ctx context.Context, scope string) context.Context {
+	return context.WithValue(ctx, ctxKeyGetTokenScope{}, scope)
+}
+
 // DoGetToken requests the OAuth2 access token from [Audience] using doer.
 // Uses clientID to determine the OAuth 2 scope and clientAssertion for authentication.
 // Will apply a user agent if [WithGetTokenUserAgent] was used in ctx.
+// Will use a different token URL if [WithGetTokenURL] was used in ctx.
+// Will use an explicit scope if [WithGetTokenScope] was used in ctx.
 func DoGetToken(ctx context.Context, doer Doer, clientID, clientAssertion string) (*TokenResponse, error) {
 	if doer == nil {
 		return nil, errors.New("nil doer")
@@ -133,9 +164,10 @@ func DoGetToken(ctx context.Context, doer Doer, clientID, clientAssertion string
 		return nil, errors.New("empty clientAssertion")
 	}
 
-	scope := "school.api"
-	if strings.HasPrefix(clientID, "BUSINESSAPI.") {
-		scope = "business.api"
+	scope, _ := ctx.Value(ctxKeyGetTokenScope{}).(string)
+	if scope == "" {
+		// legacy: no explicit scope
+		scope = ScopeForClientID(clientID)
 	}
 
 	form := url.Values{
@@ -146,10 +178,15 @@ func DoGetToken(ctx context.Context, doer Doer, clientID, clientAssertion string
 		"scope":                 {scope},
 	}
 
+	tokenURL := Audience
+	if u, _ := ctx.Value(ctxKeyGetTokenURL{}).(string); u != "" {
+		tokenURL = u
+	}
+
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
-		Audience,
+		tokenURL,
 		strings.NewReader(form.Encode()),
 	)
 	if err != nil {
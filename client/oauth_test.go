@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/micromdm/nanoaxm/storage"
+)
+
+// scopeDoer records the scope of access token requests.
+type scopeDoer struct {
+	scope string
+}
+
+func (d *scopeDoer) Do(req *http.Request) (*http.Response, error) {
+	if err := req.ParseForm(); err != nil {
+		return nil, err
+	}
+	d.scope = req.PostForm.Get("scope")
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`)),
+	}, nil
+}
+
+func TestDoGetTokenScope(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		clientID string
+		scope    string
+		want     string
+	}{
+		{"legacy business", "BUSINESSAPI.test-client-id", "", ScopeBusiness},
+		{"legacy school", "SCHOOLAPI.test-client-id", "", ScopeSchool},
+		{"explicit", "test-client-id", ScopeBusiness, ScopeBusiness},
+		{"explicit overrides prefix", "BUSINESSAPI.test-client-id", "future.api", "future.api"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.scope != "" {
+				ctx = WithGetTokenScope(ctx, test.scope)
+			}
+			d := new(scopeDoer)
+			if _, err := DoGetToken(ctx, d, test.clientID, "assertion"); err != nil {
+				t.Fatal(err)
+			}
+			if have, want := d.scope, test.want; have != want {
+				t.Errorf("scope: have: %q, want: %q", have, want)
+			}
+			ac := storage.AuthCredentials{ClientID: test.clientID, Scope: test.scope}
+			if have, want := ScopeForAuthCredentials(ac), test.want; have != want {
+				t.Errorf("auth creds scope: have: %q, want: %q", have, want)
+			}
+		})
+	}
+}
+
+func TestAccessTokenManagerScope(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "test")
+
+	ac, err := store.RetrieveAuthCredentials(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ac.Scope = ScopeSchool
+	if err = store.StoreAuthCredentials(ctx, "test", ac); err != nil {
+		t.Fatal(err)
+	}
+
+	d := new(scopeDoer)
+	m := NewAccessTokenManager(d, "test", store, func() string { return "jti" })
+	if _, err = m.GetOrRefreshToken(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	// the client ID of the test store has the business prefix
+	if have, want := d.scope, ScopeSchool; have != want {
+		t.Errorf("scope: have: %q, want: %q", have, want)
+	}
+}
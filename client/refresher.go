@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/micromdm/nanoaxm/storage"
+)
+
+// DefaultRefreshJitter is the default fraction of the refresh interval to randomly vary by.
+const DefaultRefreshJitter = 0.1
+
+// maxRefreshAhead limits how far ahead tokens are renewed.
+// It must stay well below the time it takes a fresh access token to
+// become due (48 minutes for Apple's one hour access tokens) otherwise
+// every refresh would renew fresh tokens.
+const maxRefreshAhead = 20 * time.Minute
+
+// Refresher proactively refreshes the tokens of the AxM names known to
+// a [Transport] in the background. Tokens that would become due before
+// the next refresh are renewed ahead of time so that requests do not
+// wait on (or fail because of) token refreshes.
+type Refresher struct {
+	t        *Transport
+	interval time.Duration
+	jitter   float64
+	errFn    func(axmName string, err error)
+	lister   storage.AuthCredentialsLister
+}
+
+// RefresherOption configures a Refresher.
+type RefresherOption func(*Refresher)
+
+// WithRefreshJitter randomly varies the refresh interval by up to
+// jitter (a fraction of the interval, e.g. 0.1 for 10%).
+// This avoids multiple instances refreshing in lockstep.
+// The jitter must be at least 0 and less than 1.
+// By default [DefaultRefreshJitter] is used.
+func WithRefreshJitter(jitter float64) RefresherOption {
+	return func(r *Refresher) {
+		r.jitter = jitter
+	}
+}
+
+// WithRefreshErrorHandler configures a callback for refresh errors.
+// Errors do not stop the refresher.
+func WithRefreshErrorHandler(errFn func(axmName string, err error)) RefresherOption {
+	return func(r *Refresher) {
+		r.errFn = errFn
+	}
+}
+
+// WithRefreshLister also refreshes the tokens of the AxM names listed
+// by lister. For example the storage backend (if it implements
+// [storage.AuthCredentialsLister]) to refresh all configured AxM names.
+// AxM names no longer listed are invalidated in the transport.
+// By default only AxM names already used with the transport are refreshed.
+func WithRefreshLister(lister storage.AuthCredentialsLister) RefresherOption {
+	return func(r *Refresher) {
+		r.lister = lister
+	}
+}
+
+// NewRefresher creates a new background token refresher for t
+// which checks the tokens every interval.
+// Tokens are renewed at most 20 minutes ahead: with intervals longer
+// than 10 minutes tokens may become due between checks and are then
+// refreshed when next used.
+// Panics if t is nil, interval is not positive, or the jitter is invalid.
+func NewRefresher(t *Transport, interval time.Duration, opts ...RefresherOption) *Refresher {
+	if t == nil {
+		panic("nil transport")
+	}
+	if interval <= 0 {
+		panic("invalid interval")
+	}
+
+	r := &Refresher{
+		t:        t,
+		interval: interval,
+		jitter:   DefaultRefreshJitter,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.jitter < 0 || r.jitter >= 1 {
+		panic("invalid jitter")
+	}
+
+	return r
+}
+
+// ahead is how far ahead of the refresh thresholds tokens are renewed.
+// It covers the longest possible wait until the next refresh
+// up to maxRefreshAhead.
+func (r *Refresher) ahead() time.Duration {
+	if ahead := 2 * r.interval; ahead < maxRefreshAhead {
+		return ahead
+	}
+	return maxRefreshAhead
+}
+
+// nextWait returns the (jittered) time to wait until the next refresh.
+func (r *Refresher) nextWait() time.Duration {
+	if r.jitter <= 0 {
+		return r.interval
+	}
+	j := time.Duration(float64(r.interval) * r.jitter)
+	return r.interval - j + time.Duration(rand.Int63n(int64(2*j)+1))
+}
+
+// RefreshAll refreshes the tokens of the AxM names known to the transport
+// (and listed by the lister, if configured) once, returning the first
+// error encountered.
+// Stops early if ctx is done.
+func (r *Refresher) RefreshAll(ctx context.Context) error {
+	var firstErr error
+	report := func(axmName string, err error) {
+		if r.errFn != nil {
+			r.errFn(axmName, err)
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if r.lister != nil {
+		names, err := r.lister.ListAXMNames(ctx)
+		if err != nil {
+			report("", fmt.Errorf("listing AxM names: %w", err))
+		} else {
+			r.prune(names)
+		}
+		for _, axmName := range names {
+			// make the AxM name known to the transport
+			if _, err = r.t.getOrNewTokenManager(ctx, axmName); err != nil {
+				report(axmName, err)
+			}
+		}
+	}
+
+	for _, m := range r.t.managers() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ar, ok := m.mgr.(aheadRefresher)
+		if !ok {
+			continue
+		}
+		if err := ar.RefreshAhead(ctx, r.ahead()); err != nil {
+			report(m.axmName, err)
+		}
+	}
+	return firstErr
+}
+
+// prune invalidates the AxM names known to the transport that are
+// not in names. For example AxM names whose auth credentials were deleted.
+func (r *Refresher) prune(names []string) {
+	listed := make(map[string]struct{}, len(names))
+	for _, axmName := range names {
+		listed[axmName] = struct{}{}
+	}
+	for _, m := range r.t.managers() {
+		if _, ok := listed[m.axmName]; !ok {
+			r.t.Invalidate(m.axmName)
+		}
+	}
+}
+
+// Run refreshes tokens every interval until ctx is done.
+// Refresh errors are reported to the error handler, if configured.
+func (r *Refresher) Run(ctx context.Context) {
+	for {
+		if err := sleepContext(ctx, r.nextWait()); err != nil {
+			return
+		}
+		r.RefreshAll(ctx)
+	}
+}
+
+// nameManager is an AxM name and its token manager.
+type nameManager struct {
+	axmName string
+	mgr     TokenManager[string]
+}
+
+// managers returns a snapshot of the token managers sorted by AxM name.
+func (t *Transport) managers() []nameManager {
+	t.mgrsMu.Lock()
+	ret := make([]nameManager, 0, len(t.mgrs))
+	for axmName, mgr := range t.mgrs {
+		ret = append(ret, nameManager{axmName: axmName, mgr: mgr})
+	}
+	t.mgrsMu.Unlock()
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].axmName < ret[j].axmName })
+	return ret
+}
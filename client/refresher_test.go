@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRefresher(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "test")
+	doer := &tokenDoer{}
+
+	tr := NewTransport(nil, doer, store, uuid.NewString)
+
+	// nothing known to the transport yet
+	if err := NewRefresher(tr, time.Second).RefreshAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := doer.calls, 0; have != want {
+		t.Errorf("token requests: have: %d, want: %d", have, want)
+	}
+
+	mgr, err := tr.getOrNewTokenManager(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = mgr.GetOrRefreshToken(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := doer.calls, 1; have != want {
+		t.Errorf("token requests: have: %d, want: %d", have, want)
+	}
+
+	// the access token is not due anywhere near the next refresh
+	if err = NewRefresher(tr, time.Second).RefreshAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := doer.calls, 1; have != want {
+		t.Errorf("token requests: have: %d, want: %d", have, want)
+	}
+
+	// long intervals do not renew the fresh access token
+	if err = NewRefresher(tr, time.Hour).RefreshAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := doer.calls, 1; have != want {
+		t.Errorf("token requests: have: %d, want: %d", have, want)
+	}
+
+	// the access token becomes due before the next refresh
+	atm, ok := mgr.(*AccessTokenManager)
+	if !ok {
+		t.Fatalf("unexpected token manager type: %T", mgr)
+	}
+	atm.due = newPctDueAt(func() time.Time { return time.Now().Add(40 * time.Minute) }, 0.8)
+	if err = NewRefresher(tr, 5*time.Minute).RefreshAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := doer.calls, 2; have != want {
+		t.Errorf("token requests: have: %d, want: %d", have, want)
+	}
+
+	// run should exit when the context is done
+	cCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		NewRefresher(tr, time.Hour).Run(cCtx)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("refresher did not stop")
+	}
+}
+
+func TestRefresherLister(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "test")
+	doer := &tokenDoer{}
+
+	tr := NewTransport(nil, doer, store, uuid.NewString)
+
+	// the AxM name is not known to the transport but listed by the store
+	if err := NewRefresher(tr, time.Second, WithRefreshLister(store)).RefreshAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := doer.calls, 1; have != want {
+		t.Errorf("token requests: have: %d, want: %d", have, want)
+	}
+
+	// the refreshed token should be used by requests
+	mgr, err := tr.getOrNewTokenManager(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = mgr.GetOrRefreshToken(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := doer.calls, 1; have != want {
+		t.Errorf("token requests: have: %d, want: %d", have, want)
+	}
+}
+
+func TestRefresherPrune(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "test")
+	doer := &tokenDoer{}
+
+	tr := NewTransport(nil, doer, store, uuid.NewString)
+	r := NewRefresher(tr, time.Second, WithRefreshLister(store))
+
+	if err := r.RefreshAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := len(tr.managers()), 1; have != want {
+		t.Fatalf("managers: have: %d, want: %d", have, want)
+	}
+
+	// the deleted AxM name should no longer be known or refreshed
+	if err := store.DeleteAuthCredentials(ctx, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RefreshAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := len(tr.managers()), 0; have != want {
+		t.Errorf("managers: have: %d, want: %d", have, want)
+	}
+}
+
+func TestRefresherAhead(t *testing.T) {
+	for _, test := range []struct {
+		interval time.Duration
+		ahead    time.Duration
+	}{
+		{time.Minute, 2 * time.Minute},
+		{10 * time.Minute, maxRefreshAhead},
+		{time.Hour, maxRefreshAhead},
+	} {
+		r := NewRefresher(&Transport{}, test.interval)
+		if have, want := r.ahead(), test.ahead; have != want {
+			t.Errorf("interval %s: ahead: have: %s, want: %s", test.interval, have, want)
+		}
+	}
+}
+
+func TestRefresherInvalidJitter(t *testing.T) {
+	for _, jitter := range []float64{-0.1, 1, 2} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("jitter %v: expected panic", jitter)
+				}
+			}()
+			NewRefresher(&Transport{}, time.Second, WithRefreshJitter(jitter))
+		}()
+	}
+
+	// the wait should always be positive
+	r := NewRefresher(&Transport{}, time.Second, WithRefreshJitter(0.99))
+	for i := 0; i < 100; i++ {
+		if wait := r.nextWait(); wait <= 0 {
+			t.Fatalf("invalid wait: %s", wait)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrBodyNotReplayable is returned when a request body can not be sent again.
+var ErrBodyNotReplayable = errors.New("transport: request body not replayable")
+
+// DefaultMaxBodyBuffer is the default maximum request body size
+// buffered for replaying requests.
+const DefaultMaxBodyBuffer = 1 << 20
+
+// defaultRetryBaseDelay is the initial backoff delay if none is configured.
+const defaultRetryBaseDelay = time.Second
+
+// RetryPolicy configures the retrying of rate limited (HTTP 429) and
+// server error (HTTP 5xx) responses.
+// Server errors are only retried for idempotent request methods.
+// Requests with bodies are only retried if the body can be replayed.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first.
+	// Values less than 2 disable retries.
+	MaxAttempts int
+
+	// MaxElapsed is the time budget for all attempts and delays.
+	// A retry is not attempted if its delay would exceed the budget.
+	// Zero means no limit.
+	MaxElapsed time.Duration
+
+	// BaseDelay is the initial backoff delay which doubles each attempt.
+	// A default of one second is used if zero.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the (pre-jitter) backoff delay. Zero means no cap.
+	// Delays specified by the server using the Retry-After header
+	// are not capped (but are still subject to MaxElapsed).
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is a reasonable retry policy for bulk AxM API use.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	MaxElapsed:  2 * time.Minute,
+	BaseDelay:   time.Second,
+	MaxDelay:    30 * time.Second,
+}
+
+// retryableStatus reports whether resp should be retried for req.
+func retryableStatus(req *http.Request, resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		switch req.Method {
+		case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt and whether
+// another attempt should be made at all.
+// The attempt number starts at 1 and elapsed is the time since the first attempt.
+func (p RetryPolicy) delay(req *http.Request, resp *http.Response, attempt int, elapsed time.Duration) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || !retryableStatus(req, resp) {
+		return 0, false
+	}
+	d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	if !ok {
+		d = p.backoff(attempt)
+	}
+	if p.MaxElapsed > 0 && elapsed+d > p.MaxElapsed {
+		return 0, false
+	}
+	return d, true
+}
+
+// backoff calculates the exponential backoff delay for attempt with jitter.
+// The jittered delay is between half and all of the exponential delay.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	if d <= 0 {
+		d = defaultRetryBaseDelay
+	}
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// parseRetryAfter parses the Retry-After header value s.
+// Both delay-seconds and HTTP-date forms are supported.
+func parseRetryAfter(s string, now time.Time) (time.Duration, bool) {
+	if s == "" {
+		return 0, false
+	}
+	if sec, err := strconv.Atoi(s); err == nil {
+		if sec < 0 {
+			return 0, false
+		}
+		return time.Duration(sec) * time.Second, true
+	}
+	t, err := http.ParseTime(s)
+	if err != nil {
+		return 0, false
+	}
+	d := t.Sub(now)
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
+
+// replayable reports whether the body of req can be sent again.
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// bufferBody reads the body of req into memory (up to limit bytes) so
+// that it can be replayed. Returns req unmodified if the body is already
+// replayable or limit is zero or less. If the body exceeds limit then a
+// request is returned with the full body intact but it is not replayable.
+func bufferBody(req *http.Request, limit int64) (*http.Request, error) {
+	if replayable(req) || limit <= 0 {
+		return req, nil
+	}
+	buf, err := io.ReadAll(io.LimitReader(req.Body, limit+1))
+	if err != nil {
+		req.Body.Close()
+		return nil, err
+	}
+	req2 := req.Clone(req.Context())
+	if int64(len(buf)) > limit {
+		req2.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(buf), req.Body), req.Body}
+		return req2, nil
+	}
+	req.Body.Close()
+	req2.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf)), nil
+	}
+	req2.Body, _ = req2.GetBody()
+	return req2, nil
+}
+
+// rewindRequest returns a copy of req with its body reset for sending again.
+// Returns [ErrBodyNotReplayable] if the body can not be reset.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	req2 := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return req2, nil
+	}
+	if req.GetBody == nil {
+		return nil, ErrBodyNotReplayable
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	req2.Body = body
+	return req2, nil
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
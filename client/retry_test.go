@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// staticTokenManager always returns the same token.
+type staticTokenManager string
+
+func (m staticTokenManager) GetOrRefreshToken(context.Context, bool) (string, error) {
+	return string(m), nil
+}
+
+// newTestTransport creates a transport with a static access token.
+func newTestTransport(opts ...TransportOption) *Transport {
+	t := NewTransport(nil, nil, nil, nil, opts...)
+	t.newMgr = func(context.Context, string) (TokenManager[string], error) {
+		return staticTokenManager("token"), nil
+	}
+	return t
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, td := range []struct {
+		value string
+		delay time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"Wed, 01 Jan 2025 00:00:30 GMT", 30 * time.Second, true},
+		{"Tue, 31 Dec 2024 23:59:00 GMT", 0, true},
+		{"soon", 0, false},
+	} {
+		delay, ok := parseRetryAfter(td.value, now)
+		if delay != td.delay || ok != td.ok {
+			t.Errorf("%q: have: %v, %v, want: %v, %v", td.value, delay, ok, td.delay, td.ok)
+		}
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, MaxElapsed: time.Minute, BaseDelay: time.Second, MaxDelay: 4 * time.Second}
+
+	get := httptest.NewRequest(http.MethodGet, "/", nil)
+	post := httptest.NewRequest(http.MethodPost, "/", nil)
+
+	resp := func(status int, retryAfter string) *http.Response {
+		r := &http.Response{StatusCode: status, Header: make(http.Header)}
+		if retryAfter != "" {
+			r.Header.Set("Retry-After", retryAfter)
+		}
+		return r
+	}
+
+	for i, td := range []struct {
+		req     *http.Request
+		resp    *http.Response
+		attempt int
+		elapsed time.Duration
+		ok      bool
+	}{
+		{get, resp(200, ""), 1, 0, false},
+		{get, resp(400, ""), 1, 0, false},
+		{get, resp(429, ""), 1, 0, true},
+		{post, resp(429, ""), 1, 0, true},
+		{get, resp(503, ""), 1, 0, true},
+		{post, resp(503, ""), 1, 0, false},
+		{get, resp(501, ""), 1, 0, false},
+		{get, resp(429, ""), 3, 0, false},
+		{get, resp(429, "30"), 1, 40 * time.Second, false},
+		{get, resp(429, "30"), 1, 20 * time.Second, true},
+	} {
+		_, ok := p.delay(td.req, td.resp, td.attempt, td.elapsed)
+		if ok != td.ok {
+			t.Errorf("index %d: have: %v, want: %v", i, ok, td.ok)
+		}
+	}
+
+	if _, ok := (RetryPolicy{}).delay(get, resp(429, ""), 1, 0); ok {
+		t.Error("zero value policy should not retry")
+	}
+
+	for attempt := 1; attempt < 10; attempt++ {
+		d := p.backoff(attempt)
+		if d < p.BaseDelay/2 || d > p.MaxDelay {
+			t.Errorf("attempt %d: backoff out of range: %v", attempt, d)
+		}
+	}
+}
+
+func TestTransportRetry(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if have, want := string(body), "hello"; have != want {
+			t.Errorf("body: have: %q, want: %q", have, want)
+		}
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	tr := newTestTransport(WithRetryPolicy(RetryPolicy{MaxAttempts: 3}))
+
+	req, err := NewRequestWithContext(context.Background(), "test", http.MethodPost, srv.URL, strings.NewReader("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if have, want := resp.StatusCode, http.StatusCreated; have != want {
+		t.Errorf("status: have: %d, want: %d", have, want)
+	}
+	if have, want := calls, 3; have != want {
+		t.Errorf("calls: have: %d, want: %d", have, want)
+	}
+
+	// without a retry policy the 429 is returned
+	calls = 0
+	tr = newTestTransport()
+	req, _ = NewRequestWithContext(context.Background(), "test", http.MethodPost, srv.URL, strings.NewReader("hello"))
+	resp, err = tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if have, want := resp.StatusCode, http.StatusTooManyRequests; have != want {
+		t.Errorf("status: have: %d, want: %d", have, want)
+	}
+	if have, want := calls, 1; have != want {
+		t.Errorf("calls: have: %d, want: %d", have, want)
+	}
+}
+
+func TestTransportUnauthorizedReplay(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if have, want := string(body), "hello"; have != want {
+			t.Errorf("body: have: %q, want: %q", have, want)
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}))
+	defer srv.Close()
+
+	// hide the body type so that GetBody is not set
+	newReq := func() *http.Request {
+		req, err := NewRequestWithContext(context.Background(), "test", http.MethodPost, srv.URL, io.NopCloser(strings.NewReader("hello")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return req
+	}
+
+	req := newReq()
+	resp, err := newTestTransport().RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if have, want := resp.StatusCode, http.StatusOK; have != want {
+		t.Errorf("status: have: %d, want: %d", have, want)
+	}
+	if have, want := calls, 2; have != want {
+		t.Errorf("calls: have: %d, want: %d", have, want)
+	}
+	if req.Header.Get("Authorization") != "" {
+		t.Error("original request should not have been modified")
+	}
+
+	// body too large to buffer
+	calls = 0
+	_, err = newTestTransport(WithMaxBodyBuffer(2)).RoundTrip(newReq())
+	if !errors.Is(err, ErrBodyNotReplayable) {
+		t.Errorf("expected body not replayable error, got: %v", err)
+	}
+	if have, want := calls, 1; have != want {
+		t.Errorf("calls: have: %d, want: %d", have, want)
+	}
+}
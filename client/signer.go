@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/micromdm/nanoaxm/cryptoutil"
+	"github.com/micromdm/nanoaxm/storage"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidSigner is returned when a signer is unsuitable for signing client assertions.
+var ErrInvalidSigner = errors.New("invalid signer")
+
+// SignerSelector selects the signer used for signing client assertions for axmName.
+// The signer must use a P-256 ECDSA key (i.e. the AxM portal key).
+// This allows the private key to live outside of storage,
+// for example in a separate signing agent or HSM.
+type SignerSelector func(ctx context.Context, axmName string, ac storage.AuthCredentials) (crypto.Signer, error)
+
+// PEMSigner parses the private key from the PEM of ac.
+// It is the default [SignerSelector].
+func PEMSigner(_ context.Context, axmName string, ac storage.AuthCredentials) (crypto.Signer, error) {
+	if len(ac.PrivateKeyPEM) == 0 {
+		return nil, fmt.Errorf("%w: no private key for %s", ErrInvalidSigner, axmName)
+	}
+	return cryptoutil.ECPrivateKeyFromPEM(ac.PrivateKeyPEM)
+}
+
+// signingMethodSigner is an ES256 JWT signing method using a [crypto.Signer].
+// It is not registered with the JWT library so that parsing is not affected.
+type signingMethodSigner struct{}
+
+func (signingMethodSigner) Alg() string {
+	return jwt.SigningMethodES256.Alg()
+}
+
+func (signingMethodSigner) Verify(signingString string, sig []byte, key interface{}) error {
+	return jwt.SigningMethodES256.Verify(signingString, sig, key)
+}
+
+// Sign signs signingString with key which must be a P-256 ECDSA [crypto.Signer].
+// The ASN.1 signature from the signer is converted to the JWS format.
+func (signingMethodSigner) Sign(signingString string, key interface{}) ([]byte, error) {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: not a signer", ErrInvalidSigner)
+	}
+	pub, ok := signer.Public().(*ecdsa.PublicKey)
+	if !ok || pub.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("%w: not a P-256 ECDSA key", ErrInvalidSigner)
+	}
+
+	digest := sha256.Sum256([]byte(signingString))
+	der, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err = asn1.Unmarshal(der, &sig); err != nil {
+		return nil, fmt.Errorf("unmarshal signature: %w", err)
+	}
+
+	// JWS ES256 signatures are the 32 byte R and S values concatenated
+	// see https://datatracker.ietf.org/doc/html/rfc7518#section-3.4
+	out := make([]byte, 64)
+	sig.R.FillBytes(out[:32])
+	sig.S.FillBytes(out[32:])
+	return out, nil
+}
+
+// NewClientAssertionWithSigner generates and returns a new client assertion signed by signer.
+// The private key field of ac is not used.
+func NewClientAssertionWithSigner(ac storage.AuthCredentials, signer crypto.Signer, audience, jti string, now time.Time, expiry time.Time) (string, error) {
+	if ac.ClientID == "" {
+		return "", fmt.Errorf("new client assertion: auth creds invalid: %w: empty client ID", storage.ErrInvalidAuthCredentials)
+	}
+	if ac.KeyID == "" {
+		return "", fmt.Errorf("new client assertion: auth creds invalid: %w: empty key ID", storage.ErrInvalidAuthCredentials)
+	}
+	if signer == nil {
+		return "", fmt.Errorf("new client assertion: %w: nil signer", ErrInvalidSigner)
+	}
+
+	token := jwt.NewWithClaims(signingMethodSigner{}, jwt.MapClaims{
+		"sub": ac.ClientID,
+		"aud": audience,
+		"iat": now.Unix(),
+		"exp": expiry.Unix(),
+		"jti": jti,
+		"iss": ac.ClientID, // team ID
+	})
+
+	token.Header["kid"] = ac.KeyID
+
+	clientAssertion, err := token.SignedString(signer)
+	if err != nil {
+		return "", fmt.Errorf("new client assertion: signing: %w", err)
+	}
+
+	return clientAssertion, nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// ClockSkew tracks the clock skew between the local host and the
+// Apple servers using the Date header of HTTP responses.
+// The Date header has a resolution of one second so smaller skews
+// are not meaningful.
+// A nil ClockSkew reports no skew.
+type ClockSkew struct {
+	skew atomic.Int64
+	seen atomic.Bool
+}
+
+// Observe updates the clock skew from the Date header of resp.
+// Responses without a (valid) Date header are ignored.
+func (c *ClockSkew) Observe(resp *http.Response) {
+	if c == nil || resp == nil {
+		return
+	}
+	now := time.Now()
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	// the Date header is truncated to the second so estimate
+	// the server time as the middle of that second.
+	c.skew.Store(int64(date.Add(500 * time.Millisecond).Sub(now)))
+	c.seen.Store(true)
+}
+
+// Skew returns the most recently observed clock skew.
+// A positive skew means the server clock is ahead of the local clock.
+// Zero is returned if no skew has been observed.
+func (c *ClockSkew) Skew() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return time.Duration(c.skew.Load())
+}
+
+// Observed returns true if any skew has been observed.
+func (c *ClockSkew) Observed() bool {
+	if c == nil {
+		return false
+	}
+	return c.seen.Load()
+}
+
+// Now returns the current time adjusted for the clock skew.
+// I.e. the estimated current time of the server.
+func (c *ClockSkew) Now() time.Time {
+	return time.Now().Add(c.Skew())
+}
+
+// skewDoer observes the clock skew from the responses of doer.
+type skewDoer struct {
+	doer Doer
+	skew *ClockSkew
+}
+
+func (d skewDoer) Do(req *http.Request) (*http.Response, error) {
+	resp, err := d.doer.Do(req)
+	if err == nil {
+		d.skew.Observe(resp)
+	}
+	return resp, err
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// withinSecond returns true if have is within a second of want.
+func withinSecond(have, want time.Duration) bool {
+	d := have - want
+	return d > -time.Second && d < time.Second
+}
+
+func TestClockSkewObserve(t *testing.T) {
+	var nilSkew *ClockSkew
+	if have, want := nilSkew.Skew(), time.Duration(0); have != want {
+		t.Errorf("nil skew: have: %v, want: %v", have, want)
+	}
+
+	c := new(ClockSkew)
+	c.Observe(&http.Response{Header: http.Header{"Date": {"invalid"}}})
+	if c.Observed() {
+		t.Error("invalid date should not be observed")
+	}
+
+	date := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	c.Observe(&http.Response{Header: http.Header{"Date": {date}}})
+	if !c.Observed() {
+		t.Error("expected skew to be observed")
+	}
+	if have, want := c.Skew(), -time.Hour; !withinSecond(have, want) {
+		t.Errorf("skew: have: %v, want: %v", have, want)
+	}
+	if have, want := time.Until(c.Now()), -time.Hour; !withinSecond(have, want) {
+		t.Errorf("now: have: %v, want: %v", have, want)
+	}
+}
+
+func TestClientAssertionSkewBackdate(t *testing.T) {
+	skew := new(ClockSkew)
+	date := time.Now().Add(-10 * time.Minute).UTC().Format(http.TimeFormat)
+	skew.Observe(&http.Response{Header: http.Header{"Date": {date}}})
+
+	m := NewClientAssertionTokenManager("test", newTestStore(t, "test"), func() string { return "jti" },
+		WithClientAssertionClockSkew(skew),
+		WithIssuedAtBackdate(time.Minute),
+	)
+
+	ca, err := m.GetOrRefreshToken(context.Background(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := make(jwt.MapClaims)
+	if _, _, err = jwt.NewParser().ParseUnverified(ca.ClientAssertion, claims); err != nil {
+		t.Fatal(err)
+	}
+	iat, err := claims.GetIssuedAt()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp, err := claims.GetExpirationTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := time.Until(iat.Time), -11*time.Minute; !withinSecond(have, want) {
+		t.Errorf("iat: have: %v, want: %v", have, want)
+	}
+	if have, want := exp.Sub(iat.Time), ClientAssertionDaysExpiry*24*time.Hour; have != want {
+		t.Errorf("validity: have: %v, want: %v", have, want)
+	}
+}
+
+// skewedServerDoer is a token endpoint with a clock skewed by skew.
+// It rejects client assertions issued in its future.
+type skewedServerDoer struct {
+	skew  time.Duration
+	calls int
+}
+
+func (d *skewedServerDoer) Do(req *http.Request) (*http.Response, error) {
+	d.calls++
+	now := time.Now().Add(d.skew)
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+			"Date":         {now.UTC().Format(http.TimeFormat)},
+		},
+		Body: io.NopCloser(strings.NewReader(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`)),
+	}
+
+	if err := req.ParseForm(); err != nil {
+		return nil, err
+	}
+	claims := make(jwt.MapClaims)
+	if _, _, err := jwt.NewParser().ParseUnverified(req.PostForm.Get("client_assertion"), claims); err != nil {
+		return nil, err
+	}
+	iat, err := claims.GetIssuedAt()
+	if err != nil {
+		return nil, err
+	}
+	if iat.After(now) {
+		resp.StatusCode = http.StatusBadRequest
+		resp.Body = io.NopCloser(strings.NewReader(`{"error":"invalid_client"}`))
+	}
+	return resp, nil
+}
+
+func TestAccessTokenManagerSkewRetry(t *testing.T) {
+	ctx := context.Background()
+	d := &skewedServerDoer{skew: -10 * time.Minute}
+
+	// the first client assertion is issued before the skew is known
+	m := NewAccessTokenManager(d, "test", newTestStore(t, "test"), uuid.NewString, WithClockSkew(new(ClockSkew)))
+	if _, err := m.GetOrRefreshToken(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := d.calls, 2; have != want {
+		t.Errorf("token requests: have: %d, want: %d", have, want)
+	}
+}
+
+func TestClientAssertionSkewChange(t *testing.T) {
+	ctx := context.Background()
+	skew := new(ClockSkew)
+	m := NewClientAssertionTokenManager("test", newTestStore(t, "test"), uuid.NewString, WithClientAssertionClockSkew(skew))
+
+	ca, err := m.GetOrRefreshToken(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// skew changes keep the (possibly shared) client assertion
+	date := time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)
+	skew.Observe(&http.Response{Header: http.Header{"Date": {date}}})
+	ca2, err := m.GetOrRefreshToken(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ca2.ClientAssertion != ca.ClientAssertion {
+		t.Error("expected same client assertion")
+	}
+
+	// but report it as skewed so a rejection issues it again
+	if !m.skewed() {
+		t.Error("expected skewed client assertion")
+	}
+	ca2, err = m.GetOrRefreshToken(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ca2.ClientAssertion == ca.ClientAssertion {
+		t.Error("expected new client assertion")
+	}
+	if m.skewed() {
+		t.Error("expected client assertion not skewed")
+	}
+}
+
+func TestTransportClockSkew(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	}))
+	defer srv.Close()
+
+	tr := newTestTransport()
+	if have, want := tr.ClockSkew(), time.Duration(0); have != want {
+		t.Errorf("skew: have: %v, want: %v", have, want)
+	}
+
+	req, err := NewRequestWithContext(context.Background(), "test", http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if have, want := tr.ClockSkew(), time.Hour; !withinSecond(have, want) {
+		t.Errorf("skew: have: %v, want: %v", have, want)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/micromdm/nanoaxm/storage"
 )
@@ -39,22 +41,171 @@ type Transport struct {
 
 	// newMgr instantiates a new token manager for the OAuth2 access token.
 	newMgr func(ctx context.Context, axmName string) (TokenManager[string], error)
+
+	// mgrOpts configures new access token managers.
+	mgrOpts []AccessTokenManagerOption
+
+	// retry configures retrying rate limited and server error responses.
+	retry RetryPolicy
+
+	// maxBodyBuffer is the maximum request body size to buffer for
+	// replaying requests that lack a GetBody function.
+	maxBodyBuffer int64
+
+	// limit is the default rate and concurrency limit for all AxM names.
+	limit Limit
+	// nameLimits overrides limit for specific AxM names.
+	nameLimits map[string]Limit
+	// limiters maps an AxM name to its limiter.
+	limiters   map[string]*limiter
+	limitersMu sync.Mutex
+
+	// skew tracks the clock skew from token and API responses.
+	skew *ClockSkew
 }
 
+// TransportOption configures a Transport.
+type TransportOption func(*Transport)
+
 // NewTransport creates a new NanoAXM HTTP transport.
 // If next is nil then the default HTTP transport is used.
-func NewTransport(next http.RoundTripper, authDoer Doer, store storage.ClientAssertionRefresher, jtiFn func() string) *Transport {
+func NewTransport(next http.RoundTripper, authDoer Doer, store storage.ClientAssertionRefresher, jtiFn func() string, opts ...TransportOption) *Transport {
 	if next == nil {
 		next = http.DefaultTransport
 	}
 
-	return &Transport{
-		next: next,
-		mgrs: make(map[string]TokenManager[string]),
-		newMgr: func(ctx context.Context, axmName string) (TokenManager[string], error) {
-			return NewAccessTokenManager(authDoer, axmName, store, jtiFn), nil
-		},
+	t := &Transport{
+		next:          next,
+		maxBodyBuffer: DefaultMaxBodyBuffer,
+		mgrs:          make(map[string]TokenManager[string]),
+		limiters:      make(map[string]*limiter),
+		skew:          new(ClockSkew),
+	}
+
+	// may be overridden by any user-supplied options
+	t.mgrOpts = []AccessTokenManagerOption{WithClockSkew(t.skew)}
+
+	t.newMgr = func(ctx context.Context, axmName string) (TokenManager[string], error) {
+		return NewAccessTokenManager(authDoer, axmName, store, jtiFn, t.mgrOpts...), nil
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// WithAccessTokenManagerOptions configures the access token managers with opts.
+// For example [WithAccessTokenStore] to share access tokens.
+func WithAccessTokenManagerOptions(opts ...AccessTokenManagerOption) TransportOption {
+	return func(t *Transport) {
+		t.mgrOpts = append(t.mgrOpts, opts...)
+	}
+}
+
+// WithTokenEndpoint configures the OAuth 2 token URL and client assertion audience.
+// Empty values use the default of [Audience].
+// For example to exercise the OAuth 2 flow against a local stand-in server.
+func WithTokenEndpoint(tokenURL, audience string) TransportOption {
+	return func(t *Transport) {
+		if tokenURL != "" {
+			t.mgrOpts = append(t.mgrOpts, WithTokenURL(tokenURL))
+		}
+		if audience != "" {
+			t.mgrOpts = append(t.mgrOpts, WithClientAssertionOptions(WithAudience(audience)))
+		}
+	}
+}
+
+// WithRetryPolicy configures the transport to retry requests according to p.
+// By default requests are not retried (other than for access token refreshes).
+func WithRetryPolicy(p RetryPolicy) TransportOption {
+	return func(t *Transport) {
+		t.retry = p
+	}
+}
+
+// WithMaxBodyBuffer configures the maximum request body size in bytes
+// that is buffered in memory so that it may be replayed for retries.
+// Only request bodies without a GetBody function are buffered.
+// Requests with larger bodies are not retried.
+// Zero or less disables buffering.
+// By default [DefaultMaxBodyBuffer] is used.
+func WithMaxBodyBuffer(n int64) TransportOption {
+	return func(t *Transport) {
+		t.maxBodyBuffer = n
+	}
+}
+
+// WithLimit configures the default rate and concurrency limit for every AxM name.
+// Each AxM name is limited independently.
+// By default requests are not limited.
+func WithLimit(l Limit) TransportOption {
+	return func(t *Transport) {
+		t.limit = l
+	}
+}
+
+// WithNameLimit configures the rate and concurrency limit for axmName.
+// Takes precedence over [WithLimit].
+// May be specified multiple times for different AxM names.
+func WithNameLimit(axmName string, l Limit) TransportOption {
+	return func(t *Transport) {
+		if t.nameLimits == nil {
+			t.nameLimits = make(map[string]Limit)
+		}
+		t.nameLimits[axmName] = l
+	}
+}
+
+// getLimiter returns the limiter for axmName.
+// Returns nil if axmName is not limited.
+func (t *Transport) getLimiter(axmName string) *limiter {
+	l, ok := t.nameLimits[axmName]
+	if !ok {
+		l = t.limit
+	}
+	if !l.enabled() {
+		return nil
+	}
+
+	t.limitersMu.Lock()
+	defer t.limitersMu.Unlock()
+
+	lim, ok := t.limiters[axmName]
+	if !ok {
+		lim = newLimiter(l)
+		t.limiters[axmName] = lim
+	}
+	return lim
+}
+
+// limitedRoundTrip performs the upstream HTTP round trip of req
+// once any rate and concurrency limits for axmName allow.
+func (t *Transport) limitedRoundTrip(ctx context.Context, axmName string, req *http.Request) (*http.Response, error) {
+	lim := t.getLimiter(axmName)
+	if lim == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	release, err := lim.wait(ctx)
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("transport: waiting for limit: %s: %w", axmName, err)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
 	}
+
+	// the request is in-flight until the body is closed
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
 }
 
 // getOrNewTokenManager either fetches the token manager for axmName or creates a new one.
@@ -82,10 +233,30 @@ func (t *Transport) getOrNewTokenManager(ctx context.Context, axmName string) (T
 	return mgr, nil
 }
 
+// Invalidate removes the cached token manager for axmName.
+// The next request for axmName creates a new token manager which
+// retrieves (or refreshes) its tokens from storage again.
+// Should be called when the auth credentials for axmName change.
+func (t *Transport) Invalidate(axmName string) {
+	t.mgrsMu.Lock()
+	defer t.mgrsMu.Unlock()
+	delete(t.mgrs, axmName)
+}
+
+// ClockSkew returns the clock skew observed from token and API responses.
+// A positive skew means the Apple servers' clock is ahead of ours.
+// Zero is returned if no skew has been observed.
+func (t *Transport) ClockSkew() time.Duration {
+	return t.skew.Skew()
+}
+
 // RoundTrip sets an OAuth2 access token header on req and performs an HTTP round trip
 // returning the response.
 // If the round trip is Unauthorized then a second round trip is
 // performed by first forcing an access token refresh.
+// Rate limited and server error responses may be retried according
+// to the configured [RetryPolicy].
+// Requests are queued if they exceed the configured [Limit] for the AxM name.
 func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// first, get the AxM name from the context
 	axmName := GetName(req.Context())
@@ -106,6 +277,43 @@ func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 		return nil, fmt.Errorf("transport: getting token manager: %s: %w", axmName, err)
 	}
 
+	// make sure we can replay the request body for any retries
+	req, err = bufferBody(req, t.maxBodyBuffer)
+	if err != nil {
+		return nil, fmt.Errorf("transport: buffering request body: %w", err)
+	}
+
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.authRoundTrip(ctx, mgr, axmName, req, attempt > 1)
+		if err != nil {
+			return resp, err
+		}
+
+		delay, ok := t.retry.delay(req, resp, attempt, time.Since(start))
+		if !ok || !replayable(req) {
+			// if we can't replay the body return the response we have
+			return resp, nil
+		}
+
+		// discard the response before we retry
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+
+		if err = sleepContext(ctx, delay); err != nil {
+			return nil, fmt.Errorf("transport: waiting to retry: %w", err)
+		}
+	}
+}
+
+// authRoundTrip sets an OAuth2 access token header on a copy of req
+// and performs an HTTP round trip returning the response.
+// If the round trip is Unauthorized then a second round trip is
+// performed by first forcing an access token refresh.
+// The request body is rewound before sending if rewind is true
+// (and always for the second round trip).
+func (t *Transport) authRoundTrip(ctx context.Context, mgr TokenManager[string], axmName string, req *http.Request, rewind bool) (*http.Response, error) {
 	// start off the request by letting the token manager(s) manage refreshing their token(s)
 	forceRefresh := false
 
@@ -116,19 +324,39 @@ getOrRefreshToken:
 		return nil, fmt.Errorf("transport: getting access token: %s: %w", axmName, err)
 	}
 
+	// copy the request so as to not modify the caller's
+	var r *http.Request
+	if rewind {
+		if r, err = rewindRequest(req); err != nil {
+			return nil, fmt.Errorf("transport: rewinding request: %s: %w", axmName, err)
+		}
+	} else {
+		r = req.Clone(req.Context())
+	}
+
 	// set the OAuth2 access token authentication
-	req.Header.Set("Authorization", "Bearer "+token)
+	r.Header.Set("Authorization", "Bearer "+token)
 
 	// perform the actual round-trip with our upstream round tripper
-	resp, err := t.next.RoundTrip(req)
+	resp, err := t.limitedRoundTrip(ctx, axmName, r)
 	if err != nil {
 		return resp, err
 	}
 
+	t.skew.Observe(resp)
+
 	if !forceRefresh && resp.StatusCode == 401 {
+		if !replayable(req) {
+			resp.Body.Close()
+			return nil, fmt.Errorf("transport: retrying unauthorized request: %s: %w", axmName, ErrBodyNotReplayable)
+		}
+
 		// if we've received an unauthorized, then try again.
 		// perhaps our token has a problem: force a refresh to try again.
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
 		forceRefresh = true
+		rewind = true
 		goto getOrRefreshToken
 	}
 
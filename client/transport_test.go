@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransportInvalidate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	tr := newTestTransport()
+	var newMgrs int
+	newMgr := tr.newMgr
+	tr.newMgr = func(ctx context.Context, axmName string) (TokenManager[string], error) {
+		newMgrs++
+		return newMgr(ctx, axmName)
+	}
+
+	roundTrip := func() {
+		t.Helper()
+		req, err := NewRequestWithContext(context.Background(), "test", http.MethodGet, srv.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := tr.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	roundTrip()
+	roundTrip()
+	if have, want := newMgrs, 1; have != want {
+		t.Errorf("new managers: have: %d, want: %d", have, want)
+	}
+
+	tr.Invalidate("test")
+
+	roundTrip()
+	if have, want := newMgrs, 2; have != want {
+		t.Errorf("new managers: have: %d, want: %d", have, want)
+	}
+}
@@ -0,0 +1,10 @@
+package main
+
+import "net"
+
+// listenUnix listens on the unix socket at path.
+// Windows has no umask: restrict access to the socket using the
+// permissions of its directory.
+func listenUnix(path string) (net.Listener, error) {
+	return net.Listen("unix", path)
+}
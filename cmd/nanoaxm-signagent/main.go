@@ -0,0 +1,66 @@
+// Command nanoaxm-signagent is a reference signing agent for NanoAXM client assertions.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	stdlog "log"
+	"net/http"
+	"os"
+
+	"github.com/micromdm/nanoaxm/signagent"
+
+	"github.com/micromdm/nanolib/envflag"
+	"github.com/micromdm/nanolib/log/stdlogfmt"
+)
+
+// overridden by -ldflags -X
+var version = "unknown"
+
+func main() {
+	var (
+		flDebug   = flag.Bool("debug", false, "log debug messages")
+		flSocket  = flag.String("socket", "nanoaxm-signagent.sock", "unix socket path to listen on")
+		flKeys    = flag.String("keys", "keys", "directory of <key ID>.pem private keys")
+		flVersion = flag.Bool("version", false, "print version and exit")
+	)
+	envflag.Parse("NANOAXM_SIGNAGENT_", []string{"version"})
+
+	if *flVersion {
+		fmt.Println(version)
+		return
+	}
+
+	logger := stdlogfmt.New(
+		stdlogfmt.WithLogger(stdlog.Default()),
+		stdlogfmt.WithDebugFlag(*flDebug),
+	)
+
+	// remove any stale socket from a previous run
+	if err := os.Remove(*flSocket); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Info("msg", "removing socket", "err", err)
+		os.Exit(1)
+	}
+
+	l, err := listenUnix(*flSocket)
+	if err != nil {
+		logger.Info("msg", "listening", "err", err)
+		os.Exit(1)
+	}
+
+	// only the owner may connect (the socket is already created with
+	// these permissions where supported)
+	if err = os.Chmod(*flSocket, 0600); err != nil {
+		logger.Info("msg", "setting socket permissions", "err", err)
+		os.Exit(1)
+	}
+
+	logger.Info("msg", "starting agent", "socket", *flSocket, "keys", *flKeys)
+	err = http.Serve(l, signagent.NewHandler(signagent.DirKeys(*flKeys)))
+	logs := []interface{}{"msg", "agent shutdown"}
+	if err != nil {
+		logs = append(logs, "err", err)
+	}
+	logger.Info(logs...)
+}
@@ -1,22 +1,32 @@
 package main
 
 import (
+	"context"
+	"crypto"
+	"errors"
 	"flag"
 	"fmt"
 	stdlog "log"
 	"math/rand"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/micromdm/nanoaxm/client"
 	axmhttp "github.com/micromdm/nanoaxm/http"
 	"github.com/micromdm/nanoaxm/http/proxy"
+	"github.com/micromdm/nanoaxm/signagent"
+	"github.com/micromdm/nanoaxm/storage"
 
 	"github.com/google/uuid"
 	"github.com/micromdm/nanolib/envflag"
 	libhttp "github.com/micromdm/nanolib/http"
 	"github.com/micromdm/nanolib/http/trace"
+	"github.com/micromdm/nanolib/log"
+	"github.com/micromdm/nanolib/log/ctxlog"
 	"github.com/micromdm/nanolib/log/stdlogfmt"
 )
 
@@ -25,6 +35,10 @@ var version = "unknown"
 
 const (
 	apiUsername = "nanoaxm"
+
+	// shutdownTimeout is how long in-flight requests may take to
+	// complete when shutting down.
+	shutdownTimeout = 10 * time.Second
 )
 
 func main() {
@@ -36,6 +50,16 @@ func main() {
 		flStorage = flag.String("storage", "file", "storage backend")
 		flDSN     = flag.String("storage-dsn", "", "storage backend data source name")
 		flOptions = flag.String("storage-options", "", "storage backend options")
+		flRate    = flag.Float64("limit-rate", 0, "per AxM name proxy requests per second limit")
+		flBurst   = flag.Int("limit-burst", 1, "per AxM name proxy request burst limit")
+		flFlight  = flag.Int("limit-inflight", 0, "per AxM name proxy concurrent requests limit")
+		flShareAT = flag.Bool("share-access-tokens", false, "share access tokens between instances using the storage backend")
+		flTokURL  = flag.String("token-url", client.Audience, "OAuth 2 token endpoint URL")
+		flTokAud  = flag.String("token-audience", client.Audience, "OAuth 2 client assertion audience")
+		flRefresh = flag.Duration("refresh-interval", 0, "interval for proactively refreshing tokens in the background")
+		flBackdt  = flag.Duration("iat-backdate", 0, "backdate the issued at time of client assertions to tolerate clock skew")
+		flCoolDn  = flag.Duration("unhealthy-cooldown", client.DefaultUnhealthyCooldown, "time to stop requesting tokens for AxM names with rejected credentials")
+		flSignAg  = flag.String("sign-agent", "", "signing agent unix socket path for AxM names without a stored private key")
 	)
 	envflag.Parse("NANOAXM_", []string{"version"})
 
@@ -61,6 +85,10 @@ func main() {
 		os.Exit(1)
 	}
 
+	// cancelled when we're asked to shut down
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/version", libhttp.NewJSONVersionHandler(version))
@@ -70,21 +98,82 @@ func main() {
 		return libhttp.NewSimpleBasicAuthHandler(h, apiUsername, *flAPIKey, "NanoAXM")
 	})
 
-	mwmux.Handle("/authcreds", axmhttp.NewAuthCredsSaveFormHandler(store, logger.With("handler", "auth-creds-save-form")))
-
 	proxyLogger := logger.With("handler", "proxy")
 
+	transportOpts := []client.TransportOption{
+		client.WithLimit(client.Limit{
+			Rate:        *flRate,
+			Burst:       *flBurst,
+			MaxInFlight: *flFlight,
+		}),
+		client.WithTokenEndpoint(*flTokURL, *flTokAud),
+		client.WithAccessTokenManagerOptions(
+			client.WithHooks(newLogHooks(logger.With("service", "token"))),
+			client.WithUnhealthyCooldown(*flCoolDn),
+		),
+	}
+
+	if *flBackdt > 0 {
+		transportOpts = append(transportOpts, client.WithAccessTokenManagerOptions(
+			client.WithClientAssertionOptions(client.WithIssuedAtBackdate(*flBackdt)),
+		))
+	}
+
+	var authCredsOpts []axmhttp.AuthCredsOption
+	if *flSignAg != "" {
+		transportOpts = append(transportOpts, client.WithAccessTokenManagerOptions(
+			client.WithClientAssertionOptions(client.WithSigner(newSignerSelector(*flSignAg))),
+		))
+		authCredsOpts = append(authCredsOpts, axmhttp.WithOptionalPrivateKey())
+	}
+
+	if *flShareAT {
+		atStore, ok := store.(storage.AccessTokenRefresher)
+		if !ok {
+			logger.Info("msg", "storage backend does not support sharing access tokens")
+			os.Exit(1)
+		}
+		transportOpts = append(transportOpts, client.WithAccessTokenManagerOptions(client.WithAccessTokenStore(atStore)))
+	}
+
+	businessTransport := client.NewTransport(http.DefaultTransport, http.DefaultClient, store, uuid.NewString, transportOpts...)
+	schoolTransport := client.NewTransport(http.DefaultTransport, http.DefaultClient, store, uuid.NewString, transportOpts...)
+
+	var wg sync.WaitGroup
+	if *flRefresh > 0 {
+		refreshLogger := logger.With("service", "refresher")
+		errFn := func(axmName string, err error) {
+			refreshLogger.Info("msg", "refreshing tokens", "name", axmName, "err", err)
+		}
+		lister, canList := store.(storage.AuthCredentialsLister)
+		for scope, t := range map[string]*client.Transport{
+			client.ScopeBusiness: businessTransport,
+			client.ScopeSchool:   schoolTransport,
+		} {
+			opts := []client.RefresherOption{client.WithRefreshErrorHandler(errFn)}
+			if canList {
+				// refresh all configured AxM names, not just the used ones
+				opts = append(opts, client.WithRefreshLister(&scopeLister{lister: lister, store: store, scope: scope}))
+			}
+			wg.Add(1)
+			go func(r *client.Refresher) {
+				defer wg.Done()
+				r.Run(ctx)
+			}(client.NewRefresher(t, *flRefresh, opts...))
+		}
+	}
+
+	// invalidate any cached tokens when the auth creds change
+	acStore := storage.NewAuthCredentialsStoreNotifier(store, businessTransport.Invalidate, schoolTransport.Invalidate)
+
+	mwmux.Handle("/authcreds", axmhttp.NewAuthCredsSaveFormHandler(acStore, logger.With("handler", "auth-creds-save-form"), authCredsOpts...))
+
 	mwmux.Handle("/proxy/business/",
 		http.StripPrefix("/proxy/business/",
 			axmhttp.DelHeaderMiddleware(
 				proxy.NewNameMiddleware(
 					proxy.New(
-						client.NewTransport(
-							http.DefaultTransport,
-							http.DefaultClient,
-							store,
-							uuid.NewString,
-						),
+						businessTransport,
 						"https://api-business.apple.com",
 						proxyLogger,
 					),
@@ -100,12 +189,7 @@ func main() {
 			axmhttp.DelHeaderMiddleware(
 				proxy.NewNameMiddleware(
 					proxy.New(
-						client.NewTransport(
-							http.DefaultTransport,
-							http.DefaultClient,
-							store,
-							uuid.NewString,
-						),
+						schoolTransport,
 						"https://api-school.apple.com",
 						proxyLogger,
 					),
@@ -119,8 +203,34 @@ func main() {
 	// init for newTraceID()
 	rand.Seed(time.Now().UnixNano())
 
+	srv := &http.Server{
+		Addr:    *flListen,
+		Handler: trace.NewTraceLoggingHandler(mux, logger.With("handler", "log"), newTraceID),
+	}
+
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Info("msg", "shutting down server", "err", err)
+		}
+	}()
+
 	logger.Info("msg", "starting server", "listen", *flListen)
-	err = http.ListenAndServe(*flListen, trace.NewTraceLoggingHandler(mux, logger.With("handler", "log"), newTraceID))
+	err = srv.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		err = nil
+	}
+
+	// stop everything (if the server failed) and wait for
+	// in-flight requests and the refreshers to finish
+	stop()
+	<-shutdownDone
+	wg.Wait()
+
 	logs := []interface{}{"msg", "server shutdown"}
 	if err != nil {
 		logs = append(logs, "err", err)
@@ -128,6 +238,34 @@ func main() {
 	logger.Info(logs...)
 }
 
+// newSignerSelector creates a signer selector which signs using the
+// stored private key if there is one and otherwise the signing agent
+// listening on socketPath.
+func newSignerSelector(socketPath string) client.SignerSelector {
+	agent := signagent.NewClient(socketPath)
+	return func(ctx context.Context, axmName string, ac storage.AuthCredentials) (crypto.Signer, error) {
+		if len(ac.PrivateKeyPEM) > 0 {
+			return client.PEMSigner(ctx, axmName, ac)
+		}
+		return agent.Select(ctx, axmName, ac)
+	}
+}
+
+// newLogHooks creates token lifecycle hooks that log to logger.
+func newLogHooks(logger log.Logger) client.Hooks {
+	return client.Hooks{
+		OnRefreshStart: func(ctx context.Context, ev client.TokenEvent) {
+			ctxlog.Logger(ctx, logger).Debug("msg", "refreshing token", "kind", ev.Kind, "name", ev.AxMName)
+		},
+		OnRefreshSuccess: func(ctx context.Context, ev client.TokenEvent) {
+			ctxlog.Logger(ctx, logger).Info("msg", "refreshed token", "kind", ev.Kind, "name", ev.AxMName, "expiry", ev.Expiry, "clock_skew", ev.ClockSkew)
+		},
+		OnRefreshFailure: func(ctx context.Context, ev client.TokenEvent) {
+			ctxlog.Logger(ctx, logger).Info("msg", "refreshing token", "kind", ev.Kind, "name", ev.AxMName, "clock_skew", ev.ClockSkew, "err", ev.Err)
+		},
+	}
+}
+
 // newTraceID generates a new HTTP trace ID for context logging.
 // Currently this just makes a random string. This would be better
 // served by e.g. https://github.com/oklog/ulid or something like
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/micromdm/nanoaxm/client"
+	"github.com/micromdm/nanoaxm/storage"
+)
+
+// scopeLister lists the AxM names whose auth credentials use scope.
+// This avoids refreshing tokens for AxM names the transport is not used for.
+type scopeLister struct {
+	lister storage.AuthCredentialsLister
+	store  storage.AuthCredentialsRetriever
+	scope  string
+}
+
+// ListAXMNames returns the sorted AxM names whose auth credentials use the scope.
+func (l *scopeLister) ListAXMNames(ctx context.Context) ([]string, error) {
+	names, err := l.lister.ListAXMNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ret []string
+	for _, axmName := range names {
+		ac, err := l.store.RetrieveAuthCredentials(ctx, axmName)
+		if errors.Is(err, storage.ErrInvalidAXMName) {
+			// deleted since listing
+			continue
+		} else if err != nil {
+			return ret, err
+		}
+		if client.ScopeForAuthCredentials(ac) == l.scope {
+			ret = append(ret, axmName)
+		}
+	}
+	return ret, nil
+}
@@ -8,9 +8,13 @@ import (
 	"github.com/micromdm/nanoaxm/storage/diskv"
 	"github.com/micromdm/nanoaxm/storage/inmem"
 	"github.com/micromdm/nanoaxm/storage/mysql"
+	"github.com/micromdm/nanoaxm/storage/pgsql"
+	"github.com/micromdm/nanoaxm/storage/sqlite"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
 	"github.com/micromdm/nanolib/log"
+	_ "modernc.org/sqlite"
 )
 
 var errOptionsNotSupported = errors.New("options not supported")
@@ -35,6 +39,19 @@ func newStore(storage, dsn, options string, _ log.Logger) (storage.AllStorage, e
 			return nil, errOptionsNotSupported
 		}
 		return mysql.New(mysql.WithDSN(dsn))
+	case "pgsql":
+		if options != "" {
+			return nil, errOptionsNotSupported
+		}
+		return pgsql.New(pgsql.WithDSN(dsn))
+	case "sqlite":
+		if options != "" {
+			return nil, errOptionsNotSupported
+		}
+		if dsn == "" {
+			dsn = "file:nanoaxm.db?_pragma=busy_timeout(5000)"
+		}
+		return sqlite.New(sqlite.WithDSN(dsn))
 	default:
 		return nil, fmt.Errorf("unknown storage type: %s", storage)
 	}
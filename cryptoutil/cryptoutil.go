@@ -14,6 +14,9 @@ import (
 // Apple School Manager portal.
 func ECPrivateKeyFromPEM(pemBytes []byte) (*ecdsa.PrivateKey, error) {
 	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
 
 	// TODO: parse it correctly first, fallback to weirdness
 	if block.Type != "EC PRIVATE KEY" {
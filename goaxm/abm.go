@@ -10,7 +10,7 @@ import (
 
 // ABMv1MDMServers calls the Apple Business Manager API "v1" to
 // Get a list of device management services in an organization.
-// Query parameters may be provided in v, otherwise nil.
+// Query parameters may be provided in v (see [Query]), otherwise nil.
 // See https://developer.apple.com/documentation/applebusinessmanagerapi/get-mdm-servers
 func (c *Client) ABMv1MDMServers(ctx context.Context, axmName string, v url.Values) (*abm.MdmServersResponseJson, error) {
 	var q string
@@ -18,10 +18,180 @@ func (c *Client) ABMv1MDMServers(ctx context.Context, axmName string, v url.Valu
 		q = "?" + v.Encode()
 	}
 	out := new(abm.MdmServersResponseJson)
-	return out, c.Do(ctx, axmName, "GET", "https://api-business.apple.com/v1/mdmServers"+q, nil, out, 0, nil)
+	return out, c.doABM(ctx, axmName, "GET", "/v1/mdmServers"+q, nil, out, 0, nil)
 }
 
+// ABMv1MDMServersAll returns an iterator over every device management service
+// in an organization by requesting each page using [Client.ABMv1MDMServers].
+// See [Paginate] for details on v, maxPages, and iteration.
+func (c *Client) ABMv1MDMServersAll(ctx context.Context, axmName string, v url.Values, maxPages int) func(yield func(abm.MdmServerJson, error) bool) {
+	return Paginate(ctx, v, maxPages, func(ctx context.Context, v url.Values) ([]abm.MdmServerJson, string, error) {
+		resp, err := c.ABMv1MDMServers(ctx, axmName, v)
+		if err != nil {
+			return nil, "", err
+		}
+		cursor, err := abmNextCursor(resp.Meta, resp.Links)
+		return resp.Data, cursor, err
+	})
+}
+
+// ABMv1MDMServerDevicesLinkages calls the Apple Business Manager API "v1" to
+// Get a list of device IDs assigned to a device management service.
+// The id is the device management service ID.
+// Query parameters may be provided in v (see [Query]), otherwise nil.
+// An error matching [ErrNotFound] is returned if the service is not found.
+// See https://developer.apple.com/documentation/applebusinessmanagerapi/get-all-device-ids-for-a-device-management-service
+func (c *Client) ABMv1MDMServerDevicesLinkages(ctx context.Context, axmName, id string, v url.Values) (*abm.MdmServerDevicesLinkagesResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(abm.MdmServerDevicesLinkagesResponseJson)
+	return out, c.doABM(ctx, axmName, http.MethodGet, "/v1/mdmServers/"+url.PathEscape(id)+"/relationships/devices"+q, nil, out, 0, []int{400, 401, 403, 404, 429})
+}
+
+// ABMv1MDMServerDevicesLinkagesAll returns an iterator over every device ID
+// assigned to a device management service by requesting each page using
+// [Client.ABMv1MDMServerDevicesLinkages].
+// See [Paginate] for details on v, maxPages, and iteration.
+func (c *Client) ABMv1MDMServerDevicesLinkagesAll(ctx context.Context, axmName, id string, v url.Values, maxPages int) func(yield func(abm.MdmServerDevicesLinkagesResponseData, error) bool) {
+	return Paginate(ctx, v, maxPages, func(ctx context.Context, v url.Values) ([]abm.MdmServerDevicesLinkagesResponseData, string, error) {
+		resp, err := c.ABMv1MDMServerDevicesLinkages(ctx, axmName, id, v)
+		if err != nil {
+			return nil, "", err
+		}
+		cursor, err := abmNextCursor(resp.Meta, resp.Links)
+		return resp.Data, cursor, err
+	})
+}
+
+// ABMv1OrgDevices calls the Apple Business Manager API "v1" to
+// Get a list of devices in an organization that enroll using Automated Device Enrollment.
+// Query parameters may be provided in v (see [Query]), otherwise nil.
+// See https://developer.apple.com/documentation/applebusinessmanagerapi/get-org-devices
+func (c *Client) ABMv1OrgDevices(ctx context.Context, axmName string, v url.Values) (*abm.OrgDevicesResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(abm.OrgDevicesResponseJson)
+	return out, c.doABM(ctx, axmName, http.MethodGet, "/v1/orgDevices"+q, nil, out, 0, nil)
+}
+
+// ABMv1OrgDevicesAll returns an iterator over every device in an organization
+// by requesting each page using [Client.ABMv1OrgDevices].
+// See [Paginate] for details on v, maxPages, and iteration.
+func (c *Client) ABMv1OrgDevicesAll(ctx context.Context, axmName string, v url.Values, maxPages int) func(yield func(abm.OrgDeviceJson, error) bool) {
+	return Paginate(ctx, v, maxPages, func(ctx context.Context, v url.Values) ([]abm.OrgDeviceJson, string, error) {
+		resp, err := c.ABMv1OrgDevices(ctx, axmName, v)
+		if err != nil {
+			return nil, "", err
+		}
+		cursor, err := abmNextCursor(resp.Meta, resp.Links)
+		return resp.Data, cursor, err
+	})
+}
+
+// ABMv1OrgDevice calls the Apple Business Manager API "v1" to
+// Get information about a device in an organization.
+// The id is the device ID (typically the serial number).
+// Query parameters may be provided in v (see [Query]), otherwise nil. For example
+// the "fields[orgDevices]" sparse fieldset parameter.
+// An error matching [ErrNotFound] is returned if the device is not found.
+// See https://developer.apple.com/documentation/applebusinessmanagerapi/get-orgdevice-information
+func (c *Client) ABMv1OrgDevice(ctx context.Context, axmName, id string, v url.Values) (*abm.OrgDeviceResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(abm.OrgDeviceResponseJson)
+	return out, c.doABM(ctx, axmName, http.MethodGet, "/v1/orgDevices/"+url.PathEscape(id)+q, nil, out, 0, []int{400, 401, 403, 404, 429})
+}
+
+// ABMv1OrgDeviceAssignedServer calls the Apple Business Manager API "v1" to
+// Get the assigned device management service information for a device.
+// The id is the device ID (typically the serial number).
+// Query parameters may be provided in v (see [Query]), otherwise nil.
+// An error matching [ErrNotFound] is returned if the device is not found.
+// See https://developer.apple.com/documentation/applebusinessmanagerapi/get-the-assigned-server-information-for-an-orgdevice
+func (c *Client) ABMv1OrgDeviceAssignedServer(ctx context.Context, axmName, id string, v url.Values) (*abm.MdmServerResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(abm.MdmServerResponseJson)
+	return out, c.doABM(ctx, axmName, http.MethodGet, "/v1/orgDevices/"+url.PathEscape(id)+"/assignedServer"+q, nil, out, 0, []int{400, 401, 403, 404, 429})
+}
+
+// ABMv1OrgDeviceAssignedServerLinkage calls the Apple Business Manager API "v1" to
+// Get the assigned device management service ID for a device.
+// The id is the device ID (typically the serial number).
+// An error matching [ErrNotFound] is returned if the device is not found.
+// See https://developer.apple.com/documentation/applebusinessmanagerapi/get-the-assigned-server-id-for-an-orgdevice
+func (c *Client) ABMv1OrgDeviceAssignedServerLinkage(ctx context.Context, axmName, id string) (*abm.OrgDeviceAssignedServerLinkageResponseJson, error) {
+	out := new(abm.OrgDeviceAssignedServerLinkageResponseJson)
+	return out, c.doABM(ctx, axmName, http.MethodGet, "/v1/orgDevices/"+url.PathEscape(id)+"/relationships/assignedServer", nil, out, 0, []int{400, 401, 403, 404, 429})
+}
+
+// ABMv1OrgDeviceAppleCareCoverage calls the Apple Business Manager API "v1" to
+// Get the AppleCare coverage information for a device.
+// The id is the device ID (typically the serial number).
+// Query parameters may be provided in v (see [Query]), otherwise nil.
+// An error matching [ErrNotFound] is returned if the device is not found.
+// See https://developer.apple.com/documentation/applebusinessmanagerapi/get-all-applecare-coverage-for-an-orgdevice
+func (c *Client) ABMv1OrgDeviceAppleCareCoverage(ctx context.Context, axmName, id string, v url.Values) (*abm.AppleCareCoverageResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(abm.AppleCareCoverageResponseJson)
+	return out, c.doABM(ctx, axmName, http.MethodGet, "/v1/orgDevices/"+url.PathEscape(id)+"/appleCareCoverage"+q, nil, out, 0, []int{400, 401, 403, 404, 429})
+}
+
+// ABMv1OrgDeviceAppleCareCoverageAll returns an iterator over every AppleCare
+// coverage for a device by requesting each page using
+// [Client.ABMv1OrgDeviceAppleCareCoverage].
+// See [Paginate] for details on v, maxPages, and iteration.
+func (c *Client) ABMv1OrgDeviceAppleCareCoverageAll(ctx context.Context, axmName, id string, v url.Values, maxPages int) func(yield func(abm.AppleCareCoverageJson, error) bool) {
+	return Paginate(ctx, v, maxPages, func(ctx context.Context, v url.Values) ([]abm.AppleCareCoverageJson, string, error) {
+		resp, err := c.ABMv1OrgDeviceAppleCareCoverage(ctx, axmName, id, v)
+		if err != nil {
+			return nil, "", err
+		}
+		cursor, err := abmNextCursor(resp.Meta, resp.Links)
+		return resp.Data, cursor, err
+	})
+}
+
+// ABMv1OrgDeviceActivities calls the Apple Business Manager API "v1" to
+// Create an organization device activity to assign or unassign devices
+// to a device management service.
+// See https://developer.apple.com/documentation/applebusinessmanagerapi/create-an-orgdeviceactivity
 func (c *Client) ABMv1OrgDeviceActivities(ctx context.Context, axmName string, req *abm.OrgDeviceActivityCreateRequestJson) (*abm.OrgDeviceActivityResponseJson, error) {
 	out := new(abm.OrgDeviceActivityResponseJson)
-	return out, c.Do(ctx, axmName, http.MethodPost, "https://api-business.apple.com/v1/orgDeviceActivities", req, out, 201, []int{400, 401, 403, 409, 422, 429})
+	return out, c.doABM(ctx, axmName, http.MethodPost, "/v1/orgDeviceActivities", req, out, 201, []int{400, 401, 403, 409, 422, 429})
+}
+
+// ABMv1OrgDeviceActivity calls the Apple Business Manager API "v1" to
+// Get information for an organization device activity.
+// The id is the activity ID returned when the activity was created.
+// Query parameters may be provided in v (see [Query]), otherwise nil.
+// An error matching [ErrNotFound] is returned if the activity is not found.
+// See https://developer.apple.com/documentation/applebusinessmanagerapi/get-orgdeviceactivity-information
+func (c *Client) ABMv1OrgDeviceActivity(ctx context.Context, axmName, id string, v url.Values) (*abm.OrgDeviceActivityResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(abm.OrgDeviceActivityResponseJson)
+	return out, c.doABM(ctx, axmName, http.MethodGet, "/v1/orgDeviceActivities/"+url.PathEscape(id)+q, nil, out, 0, []int{400, 401, 403, 404, 429})
+}
+
+// abmNextCursor determines the next page cursor from the ABM paging metadata.
+func abmNextCursor(meta *abm.PagingInformationJson, links abm.PagedDocumentLinksJson) (string, error) {
+	var metaCursor *string
+	if meta != nil {
+		metaCursor = meta.Paging.NextCursor
+	}
+	return nextCursor(metaCursor, links.Next)
 }
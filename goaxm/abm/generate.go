@@ -1,3 +1,3 @@
 package abm
 
-//go:generate go-jsonschema -p $GOPACKAGE --tags json --only-models --output schema.go apple-device-services/abm/schemas/ErrorResponse.json apple-device-services/abm/schemas/MdmServersResponse.json apple-device-services/abm/schemas/OrgDeviceActivityCreateRequest.json apple-device-services/abm/schemas/OrgDeviceActivityResponse.json
+//go:generate go-jsonschema -p $GOPACKAGE --tags json --only-models --output schema.go apple-device-services/abm/schemas/AppleCareCoverageResponse.json apple-device-services/abm/schemas/ErrorResponse.json apple-device-services/abm/schemas/MdmServerDevicesLinkagesResponse.json apple-device-services/abm/schemas/MdmServerResponse.json apple-device-services/abm/schemas/MdmServersResponse.json apple-device-services/abm/schemas/OrgDeviceActivityCreateRequest.json apple-device-services/abm/schemas/OrgDeviceActivityResponse.json apple-device-services/abm/schemas/OrgDeviceAssignedServerLinkageResponse.json apple-device-services/abm/schemas/OrgDeviceResponse.json apple-device-services/abm/schemas/OrgDevicesResponse.json
@@ -4,6 +4,75 @@ package abm
 
 import "time"
 
+// Attributes that describe an AppleCare coverage resource.
+type AppleCareCoverageAttributes struct {
+	// The agreement number of the coverage (if available).
+	AgreementNumber *string `json:"agreementNumber,omitempty"`
+
+	// The date and time of the cancellation of the coverage contract (if
+	// canceled).
+	ContractCancelDateTime *time.Time `json:"contractCancelDateTime,omitempty"`
+
+	// The description of the coverage.
+	Description *string `json:"description,omitempty"`
+
+	// The date and time of the end of the coverage.
+	EndDateTime *time.Time `json:"endDateTime,omitempty"`
+
+	// Whether the coverage has been canceled.
+	IsCanceled *bool `json:"isCanceled,omitempty"`
+
+	// Whether the coverage is renewable.
+	IsRenewable *bool `json:"isRenewable,omitempty"`
+
+	// The payment type of the coverage: ABE_SUBSCRIPTION, PAID_UP_FRONT,
+	// SUBSCRIPTION, or NONE
+	PaymentType *AppleCareCoverageAttributesPaymentType `json:"paymentType,omitempty"`
+
+	// The date and time of the start of the coverage.
+	StartDateTime *time.Time `json:"startDateTime,omitempty"`
+
+	// The status of the coverage: ACTIVE or INACTIVE
+	Status *AppleCareCoverageAttributesStatus `json:"status,omitempty"`
+}
+
+type AppleCareCoverageAttributesPaymentType string
+
+const AppleCareCoverageAttributesPaymentTypeABESUBSCRIPTION AppleCareCoverageAttributesPaymentType = "ABE_SUBSCRIPTION"
+const AppleCareCoverageAttributesPaymentTypeNONE AppleCareCoverageAttributesPaymentType = "NONE"
+const AppleCareCoverageAttributesPaymentTypePAIDUPFRONT AppleCareCoverageAttributesPaymentType = "PAID_UP_FRONT"
+const AppleCareCoverageAttributesPaymentTypeSUBSCRIPTION AppleCareCoverageAttributesPaymentType = "SUBSCRIPTION"
+
+type AppleCareCoverageAttributesStatus string
+
+const AppleCareCoverageAttributesStatusACTIVE AppleCareCoverageAttributesStatus = "ACTIVE"
+const AppleCareCoverageAttributesStatusINACTIVE AppleCareCoverageAttributesStatus = "INACTIVE"
+
+// The data structure that represents an AppleCare coverage resource for a
+// device.
+type AppleCareCoverageJson struct {
+	// The resource’s attributes.
+	Attributes *AppleCareCoverageAttributes `json:"attributes,omitempty"`
+
+	// The opaque resource ID that uniquely identifies the resource.
+	Id string `json:"id"`
+
+	// The resource type.
+	Type string `json:"type"`
+}
+
+// A response that contains a list of AppleCare coverage resources.
+type AppleCareCoverageResponseJson struct {
+	// The resource data.
+	Data []AppleCareCoverageJson `json:"data"`
+
+	// Navigational links that include the self-link.
+	Links PagedDocumentLinksJson `json:"links"`
+
+	// Paging information.
+	Meta *PagingInformationJson `json:"meta,omitempty"`
+}
+
 // Self-links to documents that can contain information for one or more resources.
 type DocumentLinksJson struct {
 	// The link that produces the current document.
@@ -94,6 +163,27 @@ const MdmServerAttributesServerTypeAPPLECONFIGURATOR MdmServerAttributesServerTy
 const MdmServerAttributesServerTypeAPPLEMDM MdmServerAttributesServerType = "APPLE_MDM"
 const MdmServerAttributesServerTypeMDM MdmServerAttributesServerType = "MDM"
 
+// The type and ID of a related resource.
+type MdmServerDevicesLinkagesResponseData struct {
+	// The opaque resource ID that uniquely identifies the resource.
+	Id string `json:"id"`
+
+	// The resource type.
+	Type string `json:"type"`
+}
+
+// A response that contains a list of related resource IDs.
+type MdmServerDevicesLinkagesResponseJson struct {
+	// The types and IDs of the related resources.
+	Data []MdmServerDevicesLinkagesResponseData `json:"data"`
+
+	// Navigational links that include the self-link.
+	Links PagedDocumentLinksJson `json:"links"`
+
+	// Paging information.
+	Meta *PagingInformationJson `json:"meta,omitempty"`
+}
+
 // The data structure that represents a device management service resource in an
 // organization.
 type MdmServerJson struct {
@@ -137,6 +227,15 @@ type MdmServerRelationshipsDevicesData struct {
 	Type interface{} `json:"type"`
 }
 
+// A response that contains a single device management service resource.
+type MdmServerResponseJson struct {
+	// The resource data.
+	Data MdmServerJson `json:"data"`
+
+	// Navigational links that include the self-link.
+	Links DocumentLinksJson `json:"links"`
+}
+
 // A response that contains a list of device management service resources.
 type MdmServersResponseJson struct {
 	// The resource data.
@@ -285,6 +384,25 @@ type OrgDeviceActivityResponseJson struct {
 // Strings that represent organization device activities.
 type OrgDeviceActivityTypeJson string
 
+// The type and ID of a related resource.
+type OrgDeviceAssignedServerLinkageResponseData struct {
+	// The opaque resource ID that uniquely identifies the resource.
+	Id string `json:"id"`
+
+	// The resource type.
+	Type string `json:"type"`
+}
+
+// A response that contains the ID of a single related device management service
+// resource.
+type OrgDeviceAssignedServerLinkageResponseJson struct {
+	// The type and ID of a related resource.
+	Data OrgDeviceAssignedServerLinkageResponseData `json:"data"`
+
+	// Navigational links that include the self-link.
+	Links DocumentLinksJson `json:"links"`
+}
+
 // Attributes that describe an organization device resource.
 type OrgDeviceAttributes struct {
 	// The date and time of adding the device to an organization.
@@ -398,6 +516,27 @@ type OrgDeviceRelationshipsAssignedServer struct {
 	Links *RelationshipLinksJson `json:"links,omitempty"`
 }
 
+// A response that contains a single organization device resource.
+type OrgDeviceResponseJson struct {
+	// The resource data.
+	Data OrgDeviceJson `json:"data"`
+
+	// Navigational links that include the self-link.
+	Links DocumentLinksJson `json:"links"`
+}
+
+// A response that contains a list of organization device resources.
+type OrgDevicesResponseJson struct {
+	// The resource data.
+	Data []OrgDeviceJson `json:"data"`
+
+	// Navigational links that include the self-link.
+	Links PagedDocumentLinksJson `json:"links"`
+
+	// Paging information.
+	Meta *PagingInformationJson `json:"meta,omitempty"`
+}
+
 // Links related to the response document, including paging links.
 type PagedDocumentLinksJson struct {
 	// The link to the first page of documents.
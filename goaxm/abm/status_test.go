@@ -0,0 +1,44 @@
+package abm
+
+import "testing"
+
+func TestOrgDeviceActivityStatus(t *testing.T) {
+	for _, td := range []struct {
+		status   OrgDeviceActivityAttributesStatus
+		terminal bool
+		success  bool
+	}{
+		{OrgDeviceActivityAttributesStatusINPROGRESS, false, false},
+		{OrgDeviceActivityAttributesStatusCOMPLETED, true, true},
+		{OrgDeviceActivityAttributesStatusFAILED, true, false},
+		{OrgDeviceActivityAttributesStatusSTOPPED, true, false},
+		{"", false, false},
+	} {
+		if have, want := td.status.IsTerminal(), td.terminal; have != want {
+			t.Errorf("terminal: %q: have: %v, want: %v", td.status, have, want)
+		}
+		if have, want := td.status.IsSuccess(), td.success; have != want {
+			t.Errorf("success: %q: have: %v, want: %v", td.status, have, want)
+		}
+	}
+
+	for _, td := range []struct {
+		subStatus OrgDeviceActivityAttributesSubStatus
+		terminal  bool
+		success   bool
+	}{
+		{OrgDeviceActivityAttributesSubStatusSUBMITTED, false, false},
+		{OrgDeviceActivityAttributesSubStatusPROCESSING, false, false},
+		{OrgDeviceActivityAttributesSubStatusSTOPPING, false, false},
+		{OrgDeviceActivityAttributesSubStatusCOMPLETEDWITHSUCCESS, true, true},
+		{OrgDeviceActivityAttributesSubStatusCOMPLETEDWITHERROR, true, false},
+		{OrgDeviceActivityAttributesSubStatusCOMPLETEDPOSTPROCESSINGFAILED, true, false},
+	} {
+		if have, want := td.subStatus.IsTerminal(), td.terminal; have != want {
+			t.Errorf("terminal: %q: have: %v, want: %v", td.subStatus, have, want)
+		}
+		if have, want := td.subStatus.IsSuccess(), td.success; have != want {
+			t.Errorf("success: %q: have: %v, want: %v", td.subStatus, have, want)
+		}
+	}
+}
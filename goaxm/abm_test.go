@@ -0,0 +1,230 @@
+package goaxm
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/micromdm/nanoaxm/goaxm/abm"
+	"github.com/micromdm/nanoaxm/storage"
+	"github.com/micromdm/nanoaxm/storage/inmem"
+)
+
+const testAxMName = "test-axm-name"
+
+// rewriteTransport sends all requests to the URL in u.
+type rewriteTransport struct {
+	u *url.URL
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req2 := req.Clone(req.Context())
+	req2.URL.Scheme = t.u.Scheme
+	req2.URL.Host = t.u.Host
+	return http.DefaultTransport.RoundTrip(req2)
+}
+
+// newTestStore creates a new storage with auth credentials for [testAxMName].
+func newTestStore(t *testing.T) storage.AllStorage {
+	t.Helper()
+
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKeyBytes, err := x509.MarshalECPrivateKey(privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := inmem.New()
+	err = store.StoreAuthCredentials(context.Background(), testAxMName, storage.AuthCredentials{
+		ClientID:      "BUSINESSAPI.test-client-id",
+		KeyID:         "test-key-id",
+		PrivateKeyPEM: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privKeyBytes}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return store
+}
+
+// newTestClient creates a new client which talks to a test server using h.
+// The OAuth 2 token endpoint is handled for the AxM API handler h.
+func newTestClient(t *testing.T, h http.Handler, opts ...Option) *Client {
+	t.Helper()
+	return newTestClientWithStore(t, newTestStore(t), h, opts...)
+}
+
+// newTestClientWithStore is like newTestClient but uses store.
+func newTestClientWithStore(t *testing.T, store storage.ClientAssertionRefresher, h http.Handler, opts ...Option) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/oauth2/v2/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"test-access-token","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.Handle("/v1/", h)
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts = append([]Option{WithClient(&http.Client{Transport: &rewriteTransport{u: u}})}, opts...)
+	return NewClient(store, opts...)
+}
+
+func TestABMv1OrgDevices(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.URL.Path, "/v1/orgDevices"; have != want {
+			t.Errorf("path: have: %q, want: %q", have, want)
+		}
+		if have, want := r.Header.Get("Authorization"), "Bearer test-access-token"; have != want {
+			t.Errorf("auth header: have: %q, want: %q", have, want)
+		}
+		w.Write([]byte(`{"data":[{"id":"SERIAL1","type":"orgDevices","attributes":{"serialNumber":"SERIAL1"}}],"links":{"self":"x"}}`))
+	}))
+
+	resp, err := c.ABMv1OrgDevices(context.Background(), testAxMName, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := len(resp.Data), 1; have != want {
+		t.Fatalf("data: have: %d, want: %d", have, want)
+	}
+	if have, want := *resp.Data[0].Attributes.SerialNumber, "SERIAL1"; have != want {
+		t.Errorf("serial: have: %q, want: %q", have, want)
+	}
+}
+
+func TestABMv1OrgDevice(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/orgDevices/SERIAL1" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"status":"404","code":"NOT_FOUND","title":"Not found","detail":"Not found"}]}`))
+			return
+		}
+		if have, want := r.URL.Query().Get("fields[orgDevices]"), "status"; have != want {
+			t.Errorf("fields: have: %q, want: %q", have, want)
+		}
+		w.Write([]byte(`{"data":{"id":"SERIAL1","type":"orgDevices","attributes":{"status":"ASSIGNED"}},"links":{"self":"x"}}`))
+	}))
+
+	resp, err := c.ABMv1OrgDevice(context.Background(), testAxMName, "SERIAL1", url.Values{"fields[orgDevices]": {"status"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := resp.Data.Id, "SERIAL1"; have != want {
+		t.Errorf("id: have: %q, want: %q", have, want)
+	}
+
+	_, err = c.ABMv1OrgDevice(context.Background(), testAxMName, "SERIAL2", nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+	var abmErr *ABMErrorResponseError
+	if !errors.As(err, &abmErr) {
+		t.Errorf("expected ABM error response, got: %v", err)
+	}
+}
+
+func TestABMv1OrgDeviceAssignedServer(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/orgDevices/SERIAL1/assignedServer":
+			w.Write([]byte(`{"data":{"id":"MDM1","type":"mdmServers","attributes":{"serverName":"Test MDM"}},"links":{"self":"x"}}`))
+		case "/v1/orgDevices/SERIAL1/relationships/assignedServer":
+			w.Write([]byte(`{"data":{"id":"MDM1","type":"mdmServers"},"links":{"self":"x"}}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	resp, err := c.ABMv1OrgDeviceAssignedServer(context.Background(), testAxMName, "SERIAL1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := *resp.Data.Attributes.ServerName, "Test MDM"; have != want {
+		t.Errorf("server name: have: %q, want: %q", have, want)
+	}
+
+	linkage, err := c.ABMv1OrgDeviceAssignedServerLinkage(context.Background(), testAxMName, "SERIAL1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := linkage.Data.Id, "MDM1"; have != want {
+		t.Errorf("id: have: %q, want: %q", have, want)
+	}
+}
+
+func TestABMv1MDMServerDevicesLinkages(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.URL.Path, "/v1/mdmServers/MDM1/relationships/devices"; have != want {
+			t.Errorf("path: have: %q, want: %q", have, want)
+		}
+		w.Write([]byte(`{"data":[{"id":"SERIAL1","type":"orgDevices"},{"id":"SERIAL2","type":"orgDevices"}],"links":{"self":"x"},"meta":{"paging":{"limit":100}}}`))
+	}))
+
+	resp, err := c.ABMv1MDMServerDevicesLinkages(context.Background(), testAxMName, "MDM1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := len(resp.Data), 2; have != want {
+		t.Fatalf("data: have: %d, want: %d", have, want)
+	}
+	if have, want := resp.Meta.Paging.Limit, 100; have != want {
+		t.Errorf("limit: have: %d, want: %d", have, want)
+	}
+}
+
+func TestABMv1OrgDeviceActivity(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.URL.Path, "/v1/orgDeviceActivities/ACT1"; have != want {
+			t.Errorf("path: have: %q, want: %q", have, want)
+		}
+		w.Write([]byte(`{"data":{"id":"ACT1","type":"orgDeviceActivities","attributes":{"status":"COMPLETED","subStatus":"COMPLETED_WITH_SUCCESS"}},"links":{"self":"x"}}`))
+	}))
+
+	resp, err := c.ABMv1OrgDeviceActivity(context.Background(), testAxMName, "ACT1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Data.Attributes.Status.IsTerminal() || !resp.Data.Attributes.SubStatus.IsSuccess() {
+		t.Errorf("expected terminal and successful activity: %v, %v", *resp.Data.Attributes.Status, *resp.Data.Attributes.SubStatus)
+	}
+}
+
+func TestABMv1OrgDeviceAppleCareCoverage(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.URL.Path, "/v1/orgDevices/SERIAL1/appleCareCoverage"; have != want {
+			t.Errorf("path: have: %q, want: %q", have, want)
+		}
+		w.Write([]byte(`{"data":[{"id":"COV1","type":"appleCareCoverage","attributes":{"status":"ACTIVE","isCanceled":false,"paymentType":"PAID_UP_FRONT"}}],"links":{"self":"x"},"meta":{"paging":{"limit":100}}}`))
+	}))
+
+	resp, err := c.ABMv1OrgDeviceAppleCareCoverage(context.Background(), testAxMName, "SERIAL1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := len(resp.Data), 1; have != want {
+		t.Fatalf("data: have: %d, want: %d", have, want)
+	}
+	if have, want := *resp.Data[0].Attributes.Status, abm.AppleCareCoverageAttributesStatusACTIVE; have != want {
+		t.Errorf("status: have: %q, want: %q", have, want)
+	}
+}
@@ -0,0 +1,197 @@
+package goaxm
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/micromdm/nanoaxm/goaxm/asm"
+)
+
+// ASMv1MDMServers calls the Apple School Manager API "v1" to
+// Get a list of device management services in an organization.
+// Query parameters may be provided in v (see [Query]), otherwise nil.
+// See https://developer.apple.com/documentation/appleschoolmanagerapi/get-mdm-servers
+func (c *Client) ASMv1MDMServers(ctx context.Context, axmName string, v url.Values) (*asm.MdmServersResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(asm.MdmServersResponseJson)
+	return out, c.doASM(ctx, axmName, "GET", "/v1/mdmServers"+q, nil, out, 0, nil)
+}
+
+// ASMv1MDMServersAll returns an iterator over every device management service
+// in an organization by requesting each page using [Client.ASMv1MDMServers].
+// See [Paginate] for details on v, maxPages, and iteration.
+func (c *Client) ASMv1MDMServersAll(ctx context.Context, axmName string, v url.Values, maxPages int) func(yield func(asm.MdmServerJson, error) bool) {
+	return Paginate(ctx, v, maxPages, func(ctx context.Context, v url.Values) ([]asm.MdmServerJson, string, error) {
+		resp, err := c.ASMv1MDMServers(ctx, axmName, v)
+		if err != nil {
+			return nil, "", err
+		}
+		cursor, err := asmNextCursor(resp.Meta, resp.Links)
+		return resp.Data, cursor, err
+	})
+}
+
+// ASMv1MDMServerDevicesLinkages calls the Apple School Manager API "v1" to
+// Get a list of device IDs assigned to a device management service.
+// The id is the device management service ID.
+// Query parameters may be provided in v (see [Query]), otherwise nil.
+// An error matching [ErrNotFound] is returned if the service is not found.
+// See https://developer.apple.com/documentation/appleschoolmanagerapi/get-all-device-ids-for-a-device-management-service
+func (c *Client) ASMv1MDMServerDevicesLinkages(ctx context.Context, axmName, id string, v url.Values) (*asm.MdmServerDevicesLinkagesResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(asm.MdmServerDevicesLinkagesResponseJson)
+	return out, c.doASM(ctx, axmName, http.MethodGet, "/v1/mdmServers/"+url.PathEscape(id)+"/relationships/devices"+q, nil, out, 0, []int{400, 401, 403, 404, 429})
+}
+
+// ASMv1MDMServerDevicesLinkagesAll returns an iterator over every device ID
+// assigned to a device management service by requesting each page using
+// [Client.ASMv1MDMServerDevicesLinkages].
+// See [Paginate] for details on v, maxPages, and iteration.
+func (c *Client) ASMv1MDMServerDevicesLinkagesAll(ctx context.Context, axmName, id string, v url.Values, maxPages int) func(yield func(asm.MdmServerDevicesLinkagesResponseData, error) bool) {
+	return Paginate(ctx, v, maxPages, func(ctx context.Context, v url.Values) ([]asm.MdmServerDevicesLinkagesResponseData, string, error) {
+		resp, err := c.ASMv1MDMServerDevicesLinkages(ctx, axmName, id, v)
+		if err != nil {
+			return nil, "", err
+		}
+		cursor, err := asmNextCursor(resp.Meta, resp.Links)
+		return resp.Data, cursor, err
+	})
+}
+
+// ASMv1OrgDevices calls the Apple School Manager API "v1" to
+// Get a list of devices in an organization that enroll using Automated Device Enrollment.
+// Query parameters may be provided in v (see [Query]), otherwise nil.
+// See https://developer.apple.com/documentation/appleschoolmanagerapi/get-org-devices
+func (c *Client) ASMv1OrgDevices(ctx context.Context, axmName string, v url.Values) (*asm.OrgDevicesResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(asm.OrgDevicesResponseJson)
+	return out, c.doASM(ctx, axmName, http.MethodGet, "/v1/orgDevices"+q, nil, out, 0, nil)
+}
+
+// ASMv1OrgDevicesAll returns an iterator over every device in an organization
+// by requesting each page using [Client.ASMv1OrgDevices].
+// See [Paginate] for details on v, maxPages, and iteration.
+func (c *Client) ASMv1OrgDevicesAll(ctx context.Context, axmName string, v url.Values, maxPages int) func(yield func(asm.OrgDeviceJson, error) bool) {
+	return Paginate(ctx, v, maxPages, func(ctx context.Context, v url.Values) ([]asm.OrgDeviceJson, string, error) {
+		resp, err := c.ASMv1OrgDevices(ctx, axmName, v)
+		if err != nil {
+			return nil, "", err
+		}
+		cursor, err := asmNextCursor(resp.Meta, resp.Links)
+		return resp.Data, cursor, err
+	})
+}
+
+// ASMv1OrgDevice calls the Apple School Manager API "v1" to
+// Get information about a device in an organization.
+// The id is the device ID (typically the serial number).
+// Query parameters may be provided in v (see [Query]), otherwise nil. For example
+// the "fields[orgDevices]" sparse fieldset parameter.
+// An error matching [ErrNotFound] is returned if the device is not found.
+// See https://developer.apple.com/documentation/appleschoolmanagerapi/get-orgdevice-information
+func (c *Client) ASMv1OrgDevice(ctx context.Context, axmName, id string, v url.Values) (*asm.OrgDeviceResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(asm.OrgDeviceResponseJson)
+	return out, c.doASM(ctx, axmName, http.MethodGet, "/v1/orgDevices/"+url.PathEscape(id)+q, nil, out, 0, []int{400, 401, 403, 404, 429})
+}
+
+// ASMv1OrgDeviceAssignedServer calls the Apple School Manager API "v1" to
+// Get the assigned device management service information for a device.
+// The id is the device ID (typically the serial number).
+// Query parameters may be provided in v (see [Query]), otherwise nil.
+// An error matching [ErrNotFound] is returned if the device is not found.
+// See https://developer.apple.com/documentation/appleschoolmanagerapi/get-the-assigned-server-information-for-an-orgdevice
+func (c *Client) ASMv1OrgDeviceAssignedServer(ctx context.Context, axmName, id string, v url.Values) (*asm.MdmServerResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(asm.MdmServerResponseJson)
+	return out, c.doASM(ctx, axmName, http.MethodGet, "/v1/orgDevices/"+url.PathEscape(id)+"/assignedServer"+q, nil, out, 0, []int{400, 401, 403, 404, 429})
+}
+
+// ASMv1OrgDeviceAssignedServerLinkage calls the Apple School Manager API "v1" to
+// Get the assigned device management service ID for a device.
+// The id is the device ID (typically the serial number).
+// An error matching [ErrNotFound] is returned if the device is not found.
+// See https://developer.apple.com/documentation/appleschoolmanagerapi/get-the-assigned-server-id-for-an-orgdevice
+func (c *Client) ASMv1OrgDeviceAssignedServerLinkage(ctx context.Context, axmName, id string) (*asm.OrgDeviceAssignedServerLinkageResponseJson, error) {
+	out := new(asm.OrgDeviceAssignedServerLinkageResponseJson)
+	return out, c.doASM(ctx, axmName, http.MethodGet, "/v1/orgDevices/"+url.PathEscape(id)+"/relationships/assignedServer", nil, out, 0, []int{400, 401, 403, 404, 429})
+}
+
+// ASMv1OrgDeviceAppleCareCoverage calls the Apple School Manager API "v1" to
+// Get the AppleCare coverage information for a device.
+// The id is the device ID (typically the serial number).
+// Query parameters may be provided in v (see [Query]), otherwise nil.
+// An error matching [ErrNotFound] is returned if the device is not found.
+// See https://developer.apple.com/documentation/appleschoolmanagerapi/get-all-applecare-coverage-for-an-orgdevice
+func (c *Client) ASMv1OrgDeviceAppleCareCoverage(ctx context.Context, axmName, id string, v url.Values) (*asm.AppleCareCoverageResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(asm.AppleCareCoverageResponseJson)
+	return out, c.doASM(ctx, axmName, http.MethodGet, "/v1/orgDevices/"+url.PathEscape(id)+"/appleCareCoverage"+q, nil, out, 0, []int{400, 401, 403, 404, 429})
+}
+
+// ASMv1OrgDeviceAppleCareCoverageAll returns an iterator over every AppleCare
+// coverage for a device by requesting each page using
+// [Client.ASMv1OrgDeviceAppleCareCoverage].
+// See [Paginate] for details on v, maxPages, and iteration.
+func (c *Client) ASMv1OrgDeviceAppleCareCoverageAll(ctx context.Context, axmName, id string, v url.Values, maxPages int) func(yield func(asm.AppleCareCoverageJson, error) bool) {
+	return Paginate(ctx, v, maxPages, func(ctx context.Context, v url.Values) ([]asm.AppleCareCoverageJson, string, error) {
+		resp, err := c.ASMv1OrgDeviceAppleCareCoverage(ctx, axmName, id, v)
+		if err != nil {
+			return nil, "", err
+		}
+		cursor, err := asmNextCursor(resp.Meta, resp.Links)
+		return resp.Data, cursor, err
+	})
+}
+
+// ASMv1OrgDeviceActivities calls the Apple School Manager API "v1" to
+// Create an organization device activity to assign or unassign devices
+// to a device management service.
+// See https://developer.apple.com/documentation/appleschoolmanagerapi/create-an-orgdeviceactivity
+func (c *Client) ASMv1OrgDeviceActivities(ctx context.Context, axmName string, req *asm.OrgDeviceActivityCreateRequestJson) (*asm.OrgDeviceActivityResponseJson, error) {
+	out := new(asm.OrgDeviceActivityResponseJson)
+	return out, c.doASM(ctx, axmName, http.MethodPost, "/v1/orgDeviceActivities", req, out, 201, []int{400, 401, 403, 409, 422, 429})
+}
+
+// ASMv1OrgDeviceActivity calls the Apple School Manager API "v1" to
+// Get information for an organization device activity.
+// The id is the activity ID returned when the activity was created.
+// Query parameters may be provided in v (see [Query]), otherwise nil.
+// An error matching [ErrNotFound] is returned if the activity is not found.
+// See https://developer.apple.com/documentation/appleschoolmanagerapi/get-orgdeviceactivity-information
+func (c *Client) ASMv1OrgDeviceActivity(ctx context.Context, axmName, id string, v url.Values) (*asm.OrgDeviceActivityResponseJson, error) {
+	var q string
+	if v != nil {
+		q = "?" + v.Encode()
+	}
+	out := new(asm.OrgDeviceActivityResponseJson)
+	return out, c.doASM(ctx, axmName, http.MethodGet, "/v1/orgDeviceActivities/"+url.PathEscape(id)+q, nil, out, 0, []int{400, 401, 403, 404, 429})
+}
+
+// asmNextCursor determines the next page cursor from the ASM paging metadata.
+func asmNextCursor(meta *asm.PagingInformationJson, links asm.PagedDocumentLinksJson) (string, error) {
+	var metaCursor *string
+	if meta != nil {
+		metaCursor = meta.Paging.NextCursor
+	}
+	return nextCursor(metaCursor, links.Next)
+}
@@ -0,0 +1,3 @@
+package asm
+
+//go:generate go-jsonschema -p $GOPACKAGE --tags json --only-models --output schema.go apple-device-services/asm/schemas/AppleCareCoverageResponse.json apple-device-services/asm/schemas/ErrorResponse.json apple-device-services/asm/schemas/MdmServerDevicesLinkagesResponse.json apple-device-services/asm/schemas/MdmServerResponse.json apple-device-services/asm/schemas/MdmServersResponse.json apple-device-services/asm/schemas/OrgDeviceActivityCreateRequest.json apple-device-services/asm/schemas/OrgDeviceActivityResponse.json apple-device-services/asm/schemas/OrgDeviceAssignedServerLinkageResponse.json apple-device-services/asm/schemas/OrgDeviceResponse.json apple-device-services/asm/schemas/OrgDevicesResponse.json
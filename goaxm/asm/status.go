@@ -0,0 +1,38 @@
+package asm
+
+// IsTerminal reports whether s is a final status of an activity.
+// That is, the activity will not progress any further.
+func (s OrgDeviceActivityAttributesStatus) IsTerminal() bool {
+	switch s {
+	case OrgDeviceActivityAttributesStatusCOMPLETED,
+		OrgDeviceActivityAttributesStatusFAILED,
+		OrgDeviceActivityAttributesStatusSTOPPED:
+		return true
+	}
+	return false
+}
+
+// IsSuccess reports whether s is the completed status of an activity.
+// Note that a completed activity may still have had errors or failures
+// for individual devices. Check the sub-status for those details.
+func (s OrgDeviceActivityAttributesStatus) IsSuccess() bool {
+	return s == OrgDeviceActivityAttributesStatusCOMPLETED
+}
+
+// IsTerminal reports whether s is a final sub-status of an activity.
+func (s OrgDeviceActivityAttributesSubStatus) IsTerminal() bool {
+	switch s {
+	case OrgDeviceActivityAttributesSubStatusCOMPLETEDWITHSUCCESS,
+		OrgDeviceActivityAttributesSubStatusCOMPLETEDWITHERROR,
+		OrgDeviceActivityAttributesSubStatusCOMPLETEDWITHFAILURE,
+		OrgDeviceActivityAttributesSubStatusCOMPLETEDPOSTPROCESSINGFAILED:
+		return true
+	}
+	return false
+}
+
+// IsSuccess reports whether s is the sub-status of an activity that
+// completed successfully for all devices.
+func (s OrgDeviceActivityAttributesSubStatus) IsSuccess() bool {
+	return s == OrgDeviceActivityAttributesSubStatusCOMPLETEDWITHSUCCESS
+}
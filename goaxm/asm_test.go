@@ -0,0 +1,39 @@
+package goaxm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestASMv1OrgDevice(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "api-school.apple.com" {
+			t.Errorf("unexpected host: %s", r.Host)
+		}
+		if r.URL.Path != "/v1/orgDevices/SERIAL1" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"status":"404","code":"NOT_FOUND","title":"Not found","detail":"Not found"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"id":"SERIAL1","type":"orgDevices","attributes":{"status":"ASSIGNED"}},"links":{"self":"x"}}`))
+	}))
+
+	resp, err := c.ASMv1OrgDevice(context.Background(), testAxMName, "SERIAL1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := resp.Data.Id, "SERIAL1"; have != want {
+		t.Errorf("id: have: %q, want: %q", have, want)
+	}
+
+	_, err = c.ASMv1OrgDevice(context.Background(), testAxMName, "SERIAL2", nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+	var asmErr *ASMErrorResponseError
+	if !errors.As(err, &asmErr) {
+		t.Errorf("expected ASM error response, got: %v", err)
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/micromdm/nanoaxm/client"
@@ -20,15 +23,25 @@ const (
 	acceptMediaType = "application/json"
 
 	DefaultUserAgent = "nanoaxm/0"
+
+	// BusinessBaseURL is the base URL of the Apple Business Manager API.
+	BusinessBaseURL = "https://api-business.apple.com"
+
+	// SchoolBaseURL is the base URL of the Apple School Manager API.
+	SchoolBaseURL = "https://api-school.apple.com"
 )
 
 // ErrNilClient happens when an HTTP client is not initialized.
 var ErrNilClient = errors.New("nil client")
 
 type config struct {
-	ua     string
-	client *http.Client
-	jtiFn  func() string
+	ua           string
+	client       *http.Client
+	jtiFn        func() string
+	baseURL      string
+	nameBaseURLs map[string]string
+	autoStore    storage.AuthCredentialsRetriever
+	tOpts        []client.TransportOption
 }
 
 // Client is a simple HTTP client for sending requests to
@@ -36,6 +49,14 @@ type config struct {
 type Client struct {
 	ua   string
 	doer client.Doer
+	t    *client.Transport
+
+	baseURL      string
+	nameBaseURLs map[string]string
+	autoStore    storage.AuthCredentialsRetriever
+
+	autoMu       sync.RWMutex
+	autoBaseURLs map[string]string
 }
 
 // Options configure clients.
@@ -53,14 +74,18 @@ func NewClient(store storage.ClientAssertionRefresher, opts ...Option) *Client {
 	}
 
 	// wrap the HTTP client with the NanoAxM transport
-	ct := client.ClientWithTransport(
-		cfg.client,
-		client.NewTransport(cfg.client.Transport, cfg.client, store, cfg.jtiFn),
-	)
+	t := client.NewTransport(cfg.client.Transport, cfg.client, store, cfg.jtiFn, cfg.tOpts...)
+	ct := client.ClientWithTransport(cfg.client, t)
 
 	return &Client{
 		ua:   cfg.ua,
 		doer: ct,
+		t:    t,
+
+		baseURL:      cfg.baseURL,
+		nameBaseURLs: cfg.nameBaseURLs,
+		autoStore:    cfg.autoStore,
+		autoBaseURLs: make(map[string]string),
 	}
 }
 
@@ -89,6 +114,120 @@ func WithJTI(jtiFn func() string) Option {
 	}
 }
 
+// WithTransportOptions configures the NanoAxM transport with opts.
+// For example [client.WithRetryPolicy] to retry rate limited requests.
+func WithTransportOptions(opts ...client.TransportOption) Option {
+	return func(c *config) {
+		c.tOpts = append(c.tOpts, opts...)
+	}
+}
+
+// WithTokenEndpoint configures the OAuth 2 token URL and client assertion audience.
+// Empty values use the default of [client.Audience].
+func WithTokenEndpoint(tokenURL, audience string) Option {
+	return func(c *config) {
+		c.tOpts = append(c.tOpts, client.WithTokenEndpoint(tokenURL, audience))
+	}
+}
+
+// WithBaseURL overrides the AxM API base URL for all AxM names.
+// For example to talk to a mock server or a NanoAXM proxy.
+// By default the base URL is chosen by the method called.
+// I.e. [BusinessBaseURL] for ABM methods and [SchoolBaseURL] for ASM methods.
+func WithBaseURL(baseURL string) Option {
+	return func(c *config) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithNameBaseURL overrides the AxM API base URL for axmName.
+// Takes precedence over [WithBaseURL].
+// May be specified multiple times for different AxM names.
+func WithNameBaseURL(axmName, baseURL string) Option {
+	return func(c *config) {
+		if c.nameBaseURLs == nil {
+			c.nameBaseURLs = make(map[string]string)
+		}
+		c.nameBaseURLs[axmName] = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithAutoBaseURL automatically chooses the business or school base URL
+// using the scope (or client ID) of the AxM name's auth credentials in store.
+// This happens regardless of the method called (ABM or ASM).
+// Unknown scopes use the default base URL of the method called.
+// Any base URL overrides take precedence.
+// The chosen base URL is cached per AxM name; see [Client.Invalidate].
+func WithAutoBaseURL(store storage.AuthCredentialsRetriever) Option {
+	return func(c *config) {
+		c.autoStore = store
+	}
+}
+
+// BaseURL returns the AxM API base URL for axmName.
+// The URL is resolved from (in order): per-name overrides, the base URL
+// override, the auto-selected URL, and finally defaultBaseURL.
+// Auto-selection retrieves the auth credentials from storage once
+// per AxM name and caches the result.
+func (c *Client) BaseURL(ctx context.Context, axmName, defaultBaseURL string) (string, error) {
+	if baseURL, ok := c.nameBaseURLs[axmName]; ok {
+		return baseURL, nil
+	}
+	if c.baseURL != "" {
+		return c.baseURL, nil
+	}
+	if c.autoStore != nil {
+		baseURL, err := c.autoBaseURL(ctx, axmName)
+		if err != nil {
+			return "", err
+		}
+		if baseURL != "" {
+			return baseURL, nil
+		}
+	}
+	return defaultBaseURL, nil
+}
+
+// autoBaseURL returns the (cached) auto-selected base URL for axmName.
+// An empty base URL is returned (and cached) for unknown scopes.
+func (c *Client) autoBaseURL(ctx context.Context, axmName string) (string, error) {
+	c.autoMu.RLock()
+	baseURL, ok := c.autoBaseURLs[axmName]
+	c.autoMu.RUnlock()
+	if ok {
+		return baseURL, nil
+	}
+
+	ac, err := c.autoStore.RetrieveAuthCredentials(ctx, axmName)
+	if err != nil {
+		return "", fmt.Errorf("retrieving auth creds for base URL: %w", err)
+	}
+	switch client.ScopeForAuthCredentials(ac) {
+	case client.ScopeBusiness:
+		baseURL = BusinessBaseURL
+	case client.ScopeSchool:
+		baseURL = SchoolBaseURL
+	}
+
+	c.autoMu.Lock()
+	defer c.autoMu.Unlock()
+	c.autoBaseURLs[axmName] = baseURL
+	return baseURL, nil
+}
+
+// Invalidate clears the cached auto-selected base URL and the cached
+// tokens for axmName (see [client.Transport.Invalidate]).
+// Should be called when the auth credentials for axmName change.
+// For example by passing it to [storage.NewAuthCredentialsStoreNotifier].
+func (c *Client) Invalidate(axmName string) {
+	c.autoMu.Lock()
+	delete(c.autoBaseURLs, axmName)
+	c.autoMu.Unlock()
+	if c.t != nil {
+		c.t.Invalidate(axmName)
+	}
+}
+
 // defaultOutStatus is the "success" HTTP status that will allow parsing of the "out."
 var defaultOutStatus = http.StatusOK
 
@@ -100,7 +239,43 @@ var defaultOutStatus = http.StatusOK
 // A default status will be used if outStatus is 0.
 // To parse potential errors as the AxM Error value, specify them with errStatuses.
 // A default set will be used if errStatuses is empty.
+// Errors are parsed as the ABM Error value; see [Client.DoASM] for ASM.
 func (c *Client) Do(ctx context.Context, axmName, method, url string, in, out any, outStatus int, errStatuses []int) error {
+	return c.do(ctx, axmName, method, url, in, out, outStatus, func(r *http.Response) error {
+		return ABMError(r, errStatuses)
+	})
+}
+
+// DoASM is like [Client.Do] but errors are parsed as the ASM Error value.
+func (c *Client) DoASM(ctx context.Context, axmName, method, url string, in, out any, outStatus int, errStatuses []int) error {
+	return c.do(ctx, axmName, method, url, in, out, outStatus, func(r *http.Response) error {
+		return ASMError(r, errStatuses)
+	})
+}
+
+// doABM executes the ABM HTTP call against path relative to the
+// base URL of axmName. See [Client.Do].
+func (c *Client) doABM(ctx context.Context, axmName, method, path string, in, out any, outStatus int, errStatuses []int) error {
+	baseURL, err := c.BaseURL(ctx, axmName, BusinessBaseURL)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, axmName, method, baseURL+path, in, out, outStatus, errStatuses)
+}
+
+// doASM executes the ASM HTTP call against path relative to the
+// base URL of axmName. See [Client.DoASM].
+func (c *Client) doASM(ctx context.Context, axmName, method, path string, in, out any, outStatus int, errStatuses []int) error {
+	baseURL, err := c.BaseURL(ctx, axmName, SchoolBaseURL)
+	if err != nil {
+		return err
+	}
+	return c.DoASM(ctx, axmName, method, baseURL+path, in, out, outStatus, errStatuses)
+}
+
+// do executes the AxM HTTP call. See [Client.Do].
+// Unsuccessful responses are converted to errors using errFn.
+func (c *Client) do(ctx context.Context, axmName, method, url string, in, out any, outStatus int, errFn func(*http.Response) error) error {
 	if c.doer == nil {
 		return errors.New("nil client")
 	}
@@ -138,7 +313,7 @@ func (c *Client) Do(ctx context.Context, axmName, method, url string, in, out an
 		outStatus = defaultOutStatus
 	}
 	if resp.StatusCode != outStatus {
-		return ABMError(resp, errStatuses)
+		return errFn(resp)
 	}
 
 	if out != nil {
@@ -161,7 +336,23 @@ var defaultErrStatuses = []int{
 
 // ABMError searches errStatuses for the response code in r and returns a
 // [ABMErrorResponseError] if found, otherwise an HTTP error.
+// If the response code is 404 (Not Found) then the error is
+// additionally wrapped such that it matches [ErrNotFound].
 func ABMError(r *http.Response, errStatuses []int) error {
+	return axmError(r, errStatuses, NewABMErrorResponseErrorFromReader)
+}
+
+// ASMError searches errStatuses for the response code in r and returns a
+// [ASMErrorResponseError] if found, otherwise an HTTP error.
+// If the response code is 404 (Not Found) then the error is
+// additionally wrapped such that it matches [ErrNotFound].
+func ASMError(r *http.Response, errStatuses []int) error {
+	return axmError(r, errStatuses, NewASMErrorResponseErrorFromReader)
+}
+
+// axmError searches errStatuses for the response code in r and returns
+// the error from newErr if found, otherwise an HTTP error.
+func axmError(r *http.Response, errStatuses []int, newErr func(io.Reader) error) error {
 	if errStatuses == nil {
 		errStatuses = defaultErrStatuses
 	}
@@ -172,8 +363,14 @@ func ABMError(r *http.Response, errStatuses []int) error {
 			break
 		}
 	}
+	var err error
 	if foundErrStatus {
-		return NewABMErrorResponseErrorFromReader(r.Body)
+		err = newErr(r.Body)
+	} else {
+		err = client.NewHTTPError(r)
+	}
+	if r.StatusCode == http.StatusNotFound {
+		return &notFoundError{err: err}
 	}
-	return client.NewHTTPError(r)
+	return err
 }
@@ -0,0 +1,145 @@
+package goaxm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/micromdm/nanoaxm/client"
+	"github.com/micromdm/nanoaxm/storage"
+)
+
+func TestBaseURL(t *testing.T) {
+	var host string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		w.Write([]byte(`{"data":[],"links":{"self":"x"}}`))
+	})
+
+	store := newTestStore(t)
+
+	for _, td := range []struct {
+		name string
+		opts []Option
+		asm  bool
+		host string
+	}{
+		{"default ABM", nil, false, "api-business.apple.com"},
+		{"default ASM", nil, true, "api-school.apple.com"},
+		{"base URL", []Option{WithBaseURL("http://mock.example/")}, true, "mock.example"},
+		{"name base URL", []Option{WithBaseURL("http://mock.example"), WithNameBaseURL(testAxMName, "http://name.example")}, false, "name.example"},
+		{"other name base URL", []Option{WithNameBaseURL("other", "http://name.example")}, false, "api-business.apple.com"},
+		{"auto", []Option{WithAutoBaseURL(store)}, true, "api-business.apple.com"},
+	} {
+		t.Run(td.name, func(t *testing.T) {
+			c := newTestClientWithStore(t, store, h, td.opts...)
+			var err error
+			if td.asm {
+				_, err = c.ASMv1MDMServers(context.Background(), testAxMName, nil)
+			} else {
+				_, err = c.ABMv1MDMServers(context.Background(), testAxMName, nil)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := host, td.host; have != want {
+				t.Errorf("host: have: %q, want: %q", have, want)
+			}
+		})
+	}
+}
+
+func TestAutoBaseURLInvalidate(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	c := NewClient(store, WithAutoBaseURL(store))
+	acStore := storage.NewAuthCredentialsStoreNotifier(store, c.Invalidate)
+
+	baseURL, err := c.BaseURL(ctx, testAxMName, SchoolBaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := baseURL, BusinessBaseURL; have != want {
+		t.Errorf("base URL: have: %q, want: %q", have, want)
+	}
+
+	ac, err := store.RetrieveAuthCredentials(ctx, testAxMName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ac.Scope = client.ScopeSchool
+
+	// change the scope without notifying: the cached base URL is used
+	if err = store.StoreAuthCredentials(ctx, testAxMName, ac); err != nil {
+		t.Fatal(err)
+	}
+	baseURL, err = c.BaseURL(ctx, testAxMName, SchoolBaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := baseURL, BusinessBaseURL; have != want {
+		t.Errorf("cached base URL: have: %q, want: %q", have, want)
+	}
+
+	if err = acStore.StoreAuthCredentials(ctx, testAxMName, ac); err != nil {
+		t.Fatal(err)
+	}
+	baseURL, err = c.BaseURL(ctx, testAxMName, BusinessBaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := baseURL, SchoolBaseURL; have != want {
+		t.Errorf("invalidated base URL: have: %q, want: %q", have, want)
+	}
+
+	// deleting notifies too: the credentials are retrieved again
+	if err = acStore.DeleteAuthCredentials(ctx, testAxMName); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.BaseURL(ctx, testAxMName, BusinessBaseURL)
+	if !errors.Is(err, storage.ErrInvalidAXMName) {
+		t.Errorf("expected invalid AxM name error, got: %v", err)
+	}
+}
+
+func TestTokenEndpoint(t *testing.T) {
+	var tokenRequests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests.Add(1)
+		token, _, err := jwt.NewParser().ParseUnverified(r.FormValue("client_assertion"), jwt.MapClaims{})
+		if err != nil {
+			// t.Fatal must not be called from the handler goroutine
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if have, want := token.Claims.(jwt.MapClaims)["aud"], "test-audience"; have != want {
+			t.Errorf("audience: have: %v, want: %v", have, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"test-access-token","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.HandleFunc("/v1/mdmServers", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[],"links":{"self":"x"}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := NewClient(
+		newTestStore(t),
+		WithBaseURL(srv.URL),
+		WithTokenEndpoint(srv.URL+"/token", "test-audience"),
+	)
+
+	if _, err := c.ABMv1MDMServers(context.Background(), testAxMName, nil); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := tokenRequests.Load(), int32(1); have != want {
+		t.Errorf("token requests: have: %d, want: %d", have, want)
+	}
+}
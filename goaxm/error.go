@@ -8,8 +8,33 @@ import (
 	"strings"
 
 	"github.com/micromdm/nanoaxm/goaxm/abm"
+	"github.com/micromdm/nanoaxm/goaxm/asm"
 )
 
+// ErrNotFound is matched when an AxM API resource is not found.
+var ErrNotFound = errors.New("not found")
+
+// notFoundError wraps an error resulting from an HTTP 404 (Not Found) response.
+// It matches [ErrNotFound] while still allowing access to the wrapped error.
+type notFoundError struct {
+	err error
+}
+
+// Error returns the wrapped error string prefixed with the not found error.
+func (e *notFoundError) Error() string {
+	return ErrNotFound.Error() + ": " + e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *notFoundError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is [ErrNotFound].
+func (e *notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
 // ABMErrorResponseError is an error wraps an ABM ErrorResponse JSON struct.
 // See https://developer.apple.com/documentation/applebusinessmanagerapi/errorresponse
 type ABMErrorResponseError struct {
@@ -58,3 +83,52 @@ func (e *ABMErrorResponseError) Error() string {
 
 	return "ABM error response: " + strings.Join(errStrs, ", ")
 }
+
+// ASMErrorResponseError is an error wraps an ASM ErrorResponse JSON struct.
+// See https://developer.apple.com/documentation/appleschoolmanagerapi/errorresponse
+type ASMErrorResponseError struct {
+	*asm.ErrorResponseJson
+}
+
+// NewASMErrorResponseError creates the error from the ASM ErrorResponse JSON struct.
+func NewASMErrorResponseError(errorResponse *asm.ErrorResponseJson) *ASMErrorResponseError {
+	return &ASMErrorResponseError{ErrorResponseJson: errorResponse}
+}
+
+// NewASMErrorResponseErrorFromReader decodes the ASM ErrorResponse JSON from jsonReader.
+// A new [ASMErrorResponseError] is returned or an error decoding the JSON.
+func NewASMErrorResponseErrorFromReader(jsonReader io.Reader) error {
+	if jsonReader == nil {
+		return errors.New("nil reader")
+	}
+	asmErr := new(asm.ErrorResponseJson)
+	err := json.NewDecoder(jsonReader).Decode(asmErr)
+	if err != nil {
+		return fmt.Errorf("decoding ASM error response: %w", err)
+	}
+	return NewASMErrorResponseError(asmErr)
+}
+
+// Error generates an error string based on the embedded ErrorResponse JSON struct.
+func (e *ASMErrorResponseError) Error() string {
+	if e == nil {
+		return "nil *ASMErrorResponseError"
+	}
+	if e.ErrorResponseJson == nil {
+		return "nil *ErrorResponseJson"
+	}
+	if len(e.Errors) < 1 {
+		return "empty ASM errors"
+	}
+
+	var errStrs []string
+	for i, e := range e.Errors {
+		errStr := fmt.Sprintf("error %d: %s: %s: %s", i+1, e.Detail, e.Code, e.Status)
+		if e.Id != nil {
+			errStr += ": " + *e.Id
+		}
+		errStrs = append(errStrs, errStr)
+	}
+
+	return "ASM error response: " + strings.Join(errStrs, ", ")
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/micromdm/nanoaxm/goaxm"
+	"github.com/micromdm/nanoaxm/goaxm/abm"
 	"github.com/micromdm/nanoaxm/storage/inmem"
 )
 
@@ -24,3 +25,26 @@ func Example() {
 		log.Fatal(err)
 	}
 }
+
+// Example of pulling the AppleCare coverage for every device of an AxM name.
+func ExampleClient_ABMv1OrgDeviceAppleCareCoverageAll() {
+	ctx := context.Background()
+
+	client := goaxm.NewClient(inmem.New())
+
+	client.ABMv1OrgDevicesAll(ctx, "test-axm-name", nil, 0)(func(device abm.OrgDeviceJson, err error) bool {
+		if err != nil {
+			log.Fatal(err)
+		}
+		client.ABMv1OrgDeviceAppleCareCoverageAll(ctx, "test-axm-name", device.Id, nil, 0)(func(coverage abm.AppleCareCoverageJson, err error) bool {
+			if err != nil {
+				log.Fatal(err)
+			}
+			if coverage.Attributes != nil && coverage.Attributes.Status != nil {
+				log.Println(device.Id, coverage.Id, *coverage.Attributes.Status)
+			}
+			return true
+		})
+		return true
+	})
+}
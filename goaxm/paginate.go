@@ -0,0 +1,87 @@
+package goaxm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// cursorParam is the query parameter used to request the next page.
+const cursorParam = "cursor"
+
+// ErrInvalidCursor is returned when the paging cursor of a page is
+// invalid, e.g. it repeats the cursor of the page itself.
+var ErrInvalidCursor = errors.New("invalid paging cursor")
+
+// PageFetcher fetches a single page of resources using the query parameters in v.
+// The resource data is returned along with the cursor for the next page.
+// An empty cursor indicates there are no more pages.
+// Any data returned along with an error is still yielded by [Paginate].
+type PageFetcher[T any] func(ctx context.Context, v url.Values) (data []T, nextCursor string, err error)
+
+// Paginate returns an iterator over every resource returned by fetch.
+// Pages are requested transparently by following the paging cursor.
+// Query parameters may be provided in v (see [Query]), otherwise nil;
+// v is not modified.
+// At most maxPages pages are requested; zero or less means no limit.
+//
+// The returned function is compatible with iter.Seq2[T, error].
+// Iteration stops at the first error (which is yielded) or when ctx is done.
+// A page returning the same cursor it was requested with is an error
+// (matching [ErrInvalidCursor]) rather than requested again.
+func Paginate[T any](ctx context.Context, v url.Values, maxPages int, fetch PageFetcher[T]) func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		var zero T
+		q := url.Values{}
+		for k, vs := range v {
+			q[k] = append([]string(nil), vs...)
+		}
+		for page := 0; maxPages < 1 || page < maxPages; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			data, cursor, err := fetch(ctx, q)
+			for _, d := range data {
+				if !yield(d, nil) {
+					return
+				}
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if cursor == "" {
+				return
+			}
+			if cursor == q.Get(cursorParam) {
+				yield(zero, fmt.Errorf("%w: repeated cursor: %s", ErrInvalidCursor, cursor))
+				return
+			}
+			q.Set(cursorParam, cursor)
+		}
+	}
+}
+
+// nextCursor determines the next page cursor from the paging metadata.
+// The cursor is preferred from metaCursor but falls back to extracting
+// it from the next page link. An error is returned if there is a next
+// page link but no cursor can be extracted from it.
+func nextCursor(metaCursor, nextLink *string) (string, error) {
+	if metaCursor != nil && *metaCursor != "" {
+		return *metaCursor, nil
+	}
+	if nextLink == nil || *nextLink == "" {
+		return "", nil
+	}
+	u, err := url.Parse(*nextLink)
+	if err != nil {
+		return "", fmt.Errorf("%w: parsing next link: %v", ErrInvalidCursor, err)
+	}
+	cursor := u.Query().Get(cursorParam)
+	if cursor == "" {
+		return "", fmt.Errorf("%w: no cursor in next link: %s", ErrInvalidCursor, *nextLink)
+	}
+	return cursor, nil
+}
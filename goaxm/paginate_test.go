@@ -0,0 +1,171 @@
+package goaxm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/micromdm/nanoaxm/goaxm/abm"
+)
+
+// newTestFetcher creates a fetcher returning pages of two items each.
+// The page number is encoded as the cursor.
+func newTestFetcher(pages int, calls *int) PageFetcher[string] {
+	return func(_ context.Context, v url.Values) ([]string, string, error) {
+		*calls++
+		var page int
+		if c := v.Get(cursorParam); c != "" {
+			fmt.Sscanf(c, "%d", &page)
+		}
+		var cursor string
+		if page+1 < pages {
+			cursor = fmt.Sprint(page + 1)
+		}
+		return []string{fmt.Sprintf("%d-a", page), fmt.Sprintf("%d-b", page)}, cursor, nil
+	}
+}
+
+// collect gathers all values from seq.
+func collect[T any](seq func(func(T, error) bool)) (ret []T, err error) {
+	seq(func(t T, e error) bool {
+		if e != nil {
+			err = e
+			return false
+		}
+		ret = append(ret, t)
+		return true
+	})
+	return
+}
+
+func TestPaginate(t *testing.T) {
+	ctx := context.Background()
+
+	var calls int
+	have, err := collect(Paginate(ctx, nil, 0, newTestFetcher(3, &calls)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"0-a", "0-b", "1-a", "1-b", "2-a", "2-b"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("have: %v, want: %v", have, want)
+	}
+
+	// max pages
+	calls = 0
+	have, err = collect(Paginate(ctx, nil, 2, newTestFetcher(3, &calls)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := len(have), 4; have != want {
+		t.Errorf("items: have: %d, want: %d", have, want)
+	}
+	if have, want := calls, 2; have != want {
+		t.Errorf("calls: have: %d, want: %d", have, want)
+	}
+
+	// early stop by the caller
+	calls = 0
+	Paginate(ctx, nil, 0, newTestFetcher(3, &calls))(func(string, error) bool { return false })
+	if have, want := calls, 1; have != want {
+		t.Errorf("calls: have: %d, want: %d", have, want)
+	}
+
+	// early stop by context
+	calls = 0
+	cCtx, cancel := context.WithCancel(ctx)
+	_, err = collect(Paginate(cCtx, nil, 0, func(ctx context.Context, v url.Values) ([]string, string, error) {
+		cancel()
+		return newTestFetcher(3, &calls)(ctx, v)
+	}))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled, got: %v", err)
+	}
+	if have, want := calls, 1; have != want {
+		t.Errorf("calls: have: %d, want: %d", have, want)
+	}
+
+	// a repeated cursor should not loop forever
+	calls = 0
+	have, err = collect(Paginate(ctx, nil, 0, func(context.Context, url.Values) ([]string, string, error) {
+		calls++
+		return []string{"a"}, "same", nil
+	}))
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected invalid cursor, got: %v", err)
+	}
+	if have, want := calls, 2; have != want {
+		t.Errorf("calls: have: %d, want: %d", have, want)
+	}
+	if have, want := len(have), 2; have != want {
+		t.Errorf("items: have: %d, want: %d", have, want)
+	}
+}
+
+func TestNextCursor(t *testing.T) {
+	str := func(s string) *string { return &s }
+	for _, td := range []struct {
+		name       string
+		metaCursor *string
+		nextLink   *string
+		cursor     string
+		err        bool
+	}{
+		{"none", nil, nil, "", false},
+		{"meta", str("c1"), str("https://example.com/v1/x?cursor=c2"), "c1", false},
+		{"link", nil, str("https://example.com/v1/x?cursor=c2"), "c2", false},
+		{"link without cursor", nil, str("https://example.com/v1/x?limit=1"), "", true},
+	} {
+		t.Run(td.name, func(t *testing.T) {
+			cursor, err := nextCursor(td.metaCursor, td.nextLink)
+			if have, want := err != nil, td.err; have != want {
+				t.Errorf("error: have: %v, want error: %v", err, want)
+			}
+			if td.err && !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("expected invalid cursor, got: %v", err)
+			}
+			if have, want := cursor, td.cursor; have != want {
+				t.Errorf("cursor: have: %q, want: %q", have, want)
+			}
+		})
+	}
+}
+
+func TestABMv1OrgDevicesAll(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.URL.Query().Get("limit"), "1"; have != want {
+			t.Errorf("limit: have: %q, want: %q", have, want)
+		}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			// cursor in paging metadata
+			w.Write([]byte(`{"data":[{"id":"SERIAL1","type":"orgDevices"}],"links":{"self":"x"},"meta":{"paging":{"limit":1,"nextCursor":"c1"}}}`))
+		case "c1":
+			// cursor only in the next link
+			w.Write([]byte(`{"data":[{"id":"SERIAL2","type":"orgDevices"}],"links":{"self":"x","next":"https://api-business.apple.com/v1/orgDevices?cursor=c2&limit=1"},"meta":{"paging":{"limit":1}}}`))
+		case "c2":
+			w.Write([]byte(`{"data":[{"id":"SERIAL3","type":"orgDevices"}],"links":{"self":"x"},"meta":{"paging":{"limit":1}}}`))
+		default:
+			t.Errorf("unexpected cursor: %s", r.URL.Query().Get("cursor"))
+		}
+	}))
+
+	v := url.Values{"limit": {"1"}}
+	var ids []string
+	c.ABMv1OrgDevicesAll(context.Background(), testAxMName, v, 0)(func(d abm.OrgDeviceJson, err error) bool {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, d.Id)
+		return true
+	})
+	if want := []string{"SERIAL1", "SERIAL2", "SERIAL3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("have: %v, want: %v", ids, want)
+	}
+	if v.Get(cursorParam) != "" {
+		t.Error("query parameters should not have been modified")
+	}
+}
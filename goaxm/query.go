@@ -0,0 +1,96 @@
+package goaxm
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// AxM API resource types. Used for selecting sparse fieldsets.
+const (
+	ResourceAppleCareCoverage   = "appleCareCoverage"
+	ResourceMDMServers          = "mdmServers"
+	ResourceOrgDeviceActivities = "orgDeviceActivities"
+	ResourceOrgDevices          = "orgDevices"
+)
+
+// MaxLimit is the maximum number of resources per page the AxM API allows.
+const MaxLimit = 1000
+
+var (
+	// ErrInvalidLimit is returned when a query limit is out of range.
+	ErrInvalidLimit = errors.New("invalid limit")
+
+	// ErrEmptyResourceType is returned when a query resource type is empty.
+	ErrEmptyResourceType = errors.New("empty resource type")
+
+	// ErrEmptyFilterField is returned when a query filter field is empty.
+	ErrEmptyFilterField = errors.New("empty filter field")
+)
+
+// Query is a typed set of JSON:API query parameters for AxM API calls.
+// Use [Query.Values] to encode the query for any of the methods
+// that accept query parameters.
+type Query struct {
+	// Fields selects the sparse fieldset per resource type.
+	// For example {ResourceOrgDevices: {"serialNumber", "status"}}
+	// encodes to "fields[orgDevices]=serialNumber,status".
+	Fields map[string][]string
+
+	// Filters selects resources by field value.
+	// For example {"serialNumber": {"A", "B"}}
+	// encodes to "filter[serialNumber]=A,B".
+	Filters map[string][]string
+
+	// Include specifies the related resources to include in the response.
+	Include []string
+
+	// Limit is the maximum number of resources per page.
+	// Zero uses the API default. Must not exceed [MaxLimit].
+	Limit int
+
+	// Cursor is the paging cursor to request a specific page.
+	// See [Paginate] for automatically following cursors.
+	Cursor string
+}
+
+// Values validates and encodes q into query parameters.
+// A nil q returns nil parameters.
+func (q *Query) Values() (url.Values, error) {
+	if q == nil {
+		return nil, nil
+	}
+	if q.Limit < 0 || q.Limit > MaxLimit {
+		return nil, fmt.Errorf("%w: %d: must be between 0 and %d", ErrInvalidLimit, q.Limit, MaxLimit)
+	}
+
+	v := url.Values{}
+	for resourceType, fields := range q.Fields {
+		if resourceType == "" {
+			return nil, fmt.Errorf("fields: %w", ErrEmptyResourceType)
+		}
+		if len(fields) > 0 {
+			v.Set("fields["+resourceType+"]", strings.Join(fields, ","))
+		}
+	}
+	for field, values := range q.Filters {
+		if field == "" {
+			return nil, fmt.Errorf("filters: %w", ErrEmptyFilterField)
+		}
+		if len(values) > 0 {
+			v.Set("filter["+field+"]", strings.Join(values, ","))
+		}
+	}
+	if len(q.Include) > 0 {
+		v.Set("include", strings.Join(q.Include, ","))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Cursor != "" {
+		v.Set(cursorParam, q.Cursor)
+	}
+	return v, nil
+}
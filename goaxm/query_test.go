@@ -0,0 +1,51 @@
+package goaxm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQueryValues(t *testing.T) {
+	q := &Query{
+		Fields: map[string][]string{
+			ResourceOrgDevices: {"serialNumber", "status"},
+			ResourceMDMServers: {"serverName"},
+		},
+		Filters: map[string][]string{"serialNumber": {"A", "B"}},
+		Include: []string{"devices"},
+		Limit:   MaxLimit,
+		Cursor:  "abc",
+	}
+
+	v, err := q.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "cursor=abc&fields%5BmdmServers%5D=serverName&fields%5BorgDevices%5D=serialNumber%2Cstatus&filter%5BserialNumber%5D=A%2CB&include=devices&limit=1000"
+	if have := v.Encode(); have != want {
+		t.Errorf("have: %s, want: %s", have, want)
+	}
+
+	for _, limit := range []int{-1, MaxLimit + 1} {
+		_, err = (&Query{Limit: limit}).Values()
+		if !errors.Is(err, ErrInvalidLimit) {
+			t.Errorf("limit %d: expected invalid limit error, got: %v", limit, err)
+		}
+	}
+
+	_, err = (&Query{Fields: map[string][]string{"": {"a"}}}).Values()
+	if !errors.Is(err, ErrEmptyResourceType) {
+		t.Errorf("expected empty resource type error, got: %v", err)
+	}
+
+	_, err = (&Query{Filters: map[string][]string{"": {"a"}}}).Values()
+	if !errors.Is(err, ErrEmptyFilterField) {
+		t.Errorf("expected empty filter field error, got: %v", err)
+	}
+
+	var nilQuery *Query
+	if v, err = nilQuery.Values(); v != nil || err != nil {
+		t.Errorf("expected nil values and error: %v, %v", v, err)
+	}
+}
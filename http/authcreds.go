@@ -2,6 +2,7 @@ package http
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,10 +16,31 @@ import (
 //go:embed authcreds.html
 var form []byte
 
+type authCredsConfig struct {
+	optionalKey bool
+}
+
+// AuthCredsOption configures the auth creds save form handler.
+type AuthCredsOption func(*authCredsConfig)
+
+// WithOptionalPrivateKey allows saving auth credentials without a
+// private key. For example when client assertions are signed by a
+// signing agent which holds the private key.
+func WithOptionalPrivateKey() AuthCredsOption {
+	return func(c *authCredsConfig) {
+		c.optionalKey = true
+	}
+}
+
 // NewAuthCredsSaveFormHandler creates a handler for configuring authentication credentials in store.
 // GET requests serve out an HTML form.
 // POST handles the submission of said form.
-func NewAuthCredsSaveFormHandler(store storage.AuthCredentialsStorer, logger log.Logger) http.HandlerFunc {
+func NewAuthCredsSaveFormHandler(store storage.AuthCredentialsStorer, logger log.Logger, opts ...AuthCredsOption) http.HandlerFunc {
+	cfg := new(authCredsConfig)
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
@@ -36,27 +58,37 @@ func NewAuthCredsSaveFormHandler(store storage.AuthCredentialsStorer, logger log
 			ac := storage.AuthCredentials{
 				ClientID: r.FormValue("client_id"),
 				KeyID:    r.FormValue("key_id"),
+				Scope:    r.FormValue("scope"),
 			}
 
 			file, _, err := r.FormFile("private_key")
-			if err != nil {
+			if cfg.optionalKey && errors.Is(err, http.ErrMissingFile) {
+				// no private key: leave it empty
+			} else if err != nil {
 				logger.Info("msg", "parsing form file", "err", err)
 				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 				return
-			}
-			defer file.Close()
+			} else {
+				defer file.Close()
 
-			ac.PrivateKeyPEM, err = io.ReadAll(file)
-			if err != nil {
-				logger.Info("msg", "reading form file", "err", err)
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
+				ac.PrivateKeyPEM, err = io.ReadAll(file)
+				if err != nil {
+					logger.Info("msg", "reading form file", "err", err)
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					return
+				}
+
+				_, err = cryptoutil.ECPrivateKeyFromPEM(ac.PrivateKeyPEM)
+				if err != nil {
+					logger.Info("msg", "parsing private key", "err", err)
+					http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+					return
+				}
 			}
 
-			_, err = cryptoutil.ECPrivateKeyFromPEM(ac.PrivateKeyPEM)
-			if err != nil {
-				logger.Info("msg", "parsing private key", "err", err)
-				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			if err = ac.ValidError(); err != nil {
+				logger.Info("msg", "validating auth credentials", "err", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
 
@@ -64,12 +96,12 @@ func NewAuthCredsSaveFormHandler(store storage.AuthCredentialsStorer, logger log
 
 			err = store.StoreAuthCredentials(r.Context(), axmName, ac)
 			if err != nil {
-				logger.Info("msg", "reading form file", "err", err)
+				logger.Info("msg", "storing auth credentials", "err", err)
 				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 				return
 			}
 
-			logger.Debug("msg", "stored auth credentials", "client_id", ac.ClientID)
+			logger.Debug("msg", "stored auth credentials", "client_id", ac.ClientID, "scope", ac.Scope)
 
 			fmt.Fprintf(w, "Saved authentication credentials for AXM name: %s (Client ID %s)\n", axmName, ac.ClientID)
 		default:
@@ -4,10 +4,13 @@ package proxy
 
 import (
 	"errors"
+	"math"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/micromdm/nanoaxm/client"
 
@@ -30,11 +33,24 @@ func New(transport http.RoundTripper, apiURL string, logger log.Logger) *httputi
 // newErrorHandler creates a new function for ReverseProxy.ErrorHandler.
 func newErrorHandler(logger log.Logger) func(http.ResponseWriter, *http.Request, error) {
 	return func(rw http.ResponseWriter, req *http.Request, err error) {
+		logger := ctxlog.Logger(req.Context(), logger)
+
+		var credsErr *client.CredentialsError
+		if errors.As(err, &credsErr) {
+			logger.Info("err", err)
+			// the AxM name is unavailable until the credentials are replaced
+			// (or the cool-down is over) so tell the proxy user when to retry
+			retryAfter := int(math.Ceil(time.Until(credsErr.Until).Seconds()))
+			if retryAfter > 0 {
+				rw.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			}
+			http.Error(rw, credsErr.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
 		// use the same error as the standrad reverse proxy
 		rw.WriteHeader(http.StatusBadGateway)
 
-		logger := ctxlog.Logger(req.Context(), logger)
-
 		var httpErr *client.HTTPError
 		if errors.As(err, &httpErr) {
 			logger.Info(
@@ -0,0 +1,140 @@
+package signagent
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/micromdm/nanoaxm/storage"
+)
+
+// DefaultTimeout is the default timeout for signing requests.
+const DefaultTimeout = 10 * time.Second
+
+// agentURL is the URL used for requests to the agent.
+// The host is ignored as requests are sent to the unix socket.
+const agentURL = "http://signagent"
+
+// Client talks to a signing agent over a unix socket.
+type Client struct {
+	client  *http.Client
+	timeout time.Duration
+}
+
+// NewClient creates a new signing agent client connecting to socketPath.
+func NewClient(socketPath string) *Client {
+	var d net.Dialer
+	return &Client{
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return d.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+		timeout: DefaultTimeout,
+	}
+}
+
+// do performs an agent request decoding the JSON response into v.
+func (c *Client) do(req *http.Request, v interface{}) error {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrKeyNotFound
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("agent: HTTP status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// Signer retrieves the public key for keyID from the agent and
+// returns a signer which signs using the agent.
+// Signing requests use ctx so that cancelling it also cancels them.
+func (c *Client) Signer(ctx context.Context, keyID string) (crypto.Signer, error) {
+	if keyID == "" {
+		return nil, ErrInvalidKeyID
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, agentURL+PublicKeyPath+"?key_id="+url.QueryEscape(keyID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pkResp := new(PublicKeyResponse)
+	if err = c.do(req, pkResp); err != nil {
+		return nil, fmt.Errorf("retrieving public key: %w", err)
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(pkResp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+
+	return &signer{ctx: ctx, client: c, keyID: keyID, pub: pub}, nil
+}
+
+// Select returns the agent signer for the key ID of ac.
+// It is suitable for use as a client.SignerSelector.
+func (c *Client) Select(ctx context.Context, _ string, ac storage.AuthCredentials) (crypto.Signer, error) {
+	return c.Signer(ctx, ac.KeyID)
+}
+
+// signer is a crypto.Signer which signs using the agent.
+// The crypto.Signer interface has no context so the context the
+// signer was created with is kept for the signing requests.
+type signer struct {
+	ctx    context.Context
+	client *Client
+	keyID  string
+	pub    crypto.PublicKey
+}
+
+// Public returns the public key of the agent key.
+func (s *signer) Public() crypto.PublicKey {
+	return s.pub
+}
+
+// Sign signs the SHA-256 digest using the agent.
+// The rand argument is ignored.
+func (s *signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts == nil || opts.HashFunc() != crypto.SHA256 {
+		return nil, errors.New("only SHA-256 digests supported")
+	}
+
+	body, err := json.Marshal(&SignRequest{KeyID: s.keyID, Digest: digest})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, s.client.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, agentURL+SignPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	signResp := new(SignResponse)
+	if err = s.client.do(req, signResp); err != nil {
+		return nil, fmt.Errorf("agent signing: %w", err)
+	}
+
+	return signResp.Signature, nil
+}
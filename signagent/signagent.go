@@ -0,0 +1,158 @@
+// Package signagent implements a local signing agent for client assertions.
+// The agent holds the AxM private keys and signs on behalf of NanoAXM
+// over HTTP on a unix socket so that keys need not live in storage.
+package signagent
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/micromdm/nanoaxm/cryptoutil"
+)
+
+const (
+	// PublicKeyPath is the agent endpoint for retrieving public keys.
+	PublicKeyPath = "/v1/public-key"
+
+	// SignPath is the agent endpoint for signing digests.
+	SignPath = "/v1/sign"
+)
+
+var (
+	// ErrKeyNotFound is returned when the agent has no key for a key ID.
+	ErrKeyNotFound = errors.New("key not found")
+
+	// ErrInvalidKeyID is returned for empty or otherwise invalid key IDs.
+	ErrInvalidKeyID = errors.New("invalid key ID")
+)
+
+// KeyFunc returns the signer for keyID.
+// [ErrKeyNotFound] should be returned if there is no such key.
+type KeyFunc func(keyID string) (crypto.Signer, error)
+
+// PublicKeyResponse is the response from the public key endpoint.
+type PublicKeyResponse struct {
+	// PublicKey is the PKIX DER encoded public key.
+	PublicKey []byte `json:"public_key"`
+}
+
+// SignRequest is the request to the sign endpoint.
+type SignRequest struct {
+	KeyID string `json:"key_id"`
+
+	// Digest is the SHA-256 digest to sign.
+	Digest []byte `json:"digest"`
+}
+
+// SignResponse is the response from the sign endpoint.
+type SignResponse struct {
+	// Signature is the ASN.1 DER encoded ECDSA signature.
+	Signature []byte `json:"signature"`
+}
+
+// DirKeys returns a KeyFunc which loads PEM private keys from dir.
+// Keys are read from files named by the key ID with a ".pem" extension.
+func DirKeys(dir string) KeyFunc {
+	return func(keyID string) (crypto.Signer, error) {
+		if keyID == "" || strings.ContainsAny(keyID, `/\`) || keyID == "." || keyID == ".." {
+			return nil, ErrInvalidKeyID
+		}
+		pemBytes, err := os.ReadFile(filepath.Join(dir, keyID+".pem"))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyID)
+		} else if err != nil {
+			return nil, err
+		}
+		return cryptoutil.ECPrivateKeyFromPEM(pemBytes)
+	}
+}
+
+// NewHandler creates the signing agent HTTP handler using keyFn for retrieving keys.
+func NewHandler(keyFn KeyFunc) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(PublicKeyPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		signer, ok := getSigner(w, keyFn, r.URL.Query().Get("key_id"))
+		if !ok {
+			return
+		}
+		der, err := x509.MarshalPKIXPublicKey(signer.Public())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, &PublicKeyResponse{PublicKey: der})
+	})
+
+	mux.HandleFunc(SignPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		req := new(SignRequest)
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<12)).Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(req.Digest) != crypto.SHA256.Size() {
+			http.Error(w, "invalid digest size", http.StatusBadRequest)
+			return
+		}
+		signer, ok := getSigner(w, keyFn, req.KeyID)
+		if !ok {
+			return
+		}
+		if _, ok = signer.Public().(*ecdsa.PublicKey); !ok {
+			http.Error(w, "not an ECDSA key", http.StatusInternalServerError)
+			return
+		}
+		sig, err := signer.Sign(rand.Reader, req.Digest, crypto.SHA256)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, &SignResponse{Signature: sig})
+	})
+
+	return mux
+}
+
+// getSigner retrieves the signer for keyID from keyFn.
+// Writes an HTTP error to w and returns false if it could not be retrieved.
+func getSigner(w http.ResponseWriter, keyFn KeyFunc, keyID string) (crypto.Signer, bool) {
+	if keyID == "" {
+		http.Error(w, ErrInvalidKeyID.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	signer, err := keyFn(keyID)
+	switch {
+	case errors.Is(err, ErrKeyNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return nil, false
+	case errors.Is(err, ErrInvalidKeyID):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	return signer, true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,97 @@
+package signagent
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/micromdm/nanoaxm/client"
+	"github.com/micromdm/nanoaxm/storage"
+)
+
+func TestAgent(t *testing.T) {
+	// unix socket paths are length limited so avoid long test dir names
+	dir, err := os.MkdirTemp("", "signagent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "KEYID1.pem"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	socketPath := filepath.Join(dir, "agent.sock")
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{Handler: NewHandler(DirKeys(dir))}
+	go srv.Serve(l)
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(socketPath)
+
+	if _, err = c.Signer(ctx, "KEYID2"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expected key not found, got: %v", err)
+	}
+
+	ac := storage.AuthCredentials{ClientID: "BUSINESSAPI.test-client-id", KeyID: "KEYID1"}
+	signer, err := c.Select(ctx, "test", ac)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !key.PublicKey.Equal(signer.Public()) {
+		t.Error("public key mismatch")
+	}
+
+	now := time.Now()
+	token, err := client.NewClientAssertionWithSigner(ac, signer, client.Audience, "jti", now, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := jwt.Parse(token, func(*jwt.Token) (interface{}, error) {
+		return &key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := parsed.Header["kid"], "KEYID1"; have != want {
+		t.Errorf("kid: have: %v, want: %v", have, want)
+	}
+
+	// cancelling the context of the signer should cancel signing
+	cancelCtx, cancel := context.WithCancel(ctx)
+	signer, err = c.Select(cancelCtx, "test", ac)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	_, err = client.NewClientAssertionWithSigner(ac, signer, client.Audience, "jti", now, now.Add(time.Hour))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled, got: %v", err)
+	}
+}
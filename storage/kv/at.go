@@ -0,0 +1,99 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/micromdm/nanoaxm/storage"
+	"github.com/micromdm/nanolib/storage/kv"
+)
+
+const (
+	keyPfxAT = "atkn"
+
+	keySfxATToken    = "tok"
+	keySfxATValidity = "vld"
+	keySfxATExpiry   = "exp"
+)
+
+func storeAccessToken(ctx context.Context, axmName string, b kv.RWBucket, token storage.AccessToken) error {
+	err := kv.SetMap(ctx, b, map[string][]byte{
+		join(keyPfxAT, axmName, keySfxATToken):    []byte(token.Token),
+		join(keyPfxAT, axmName, keySfxATValidity): durationToBytes(token.Validity),
+		join(keyPfxAT, axmName, keySfxATExpiry):   timeToBytes(token.Expiry),
+	})
+	if err != nil {
+		return fmt.Errorf("setting keys: %w", err)
+	}
+	return nil
+}
+
+func retrieveAccessToken(ctx context.Context, axmName string, b kv.ROBucket) (storage.AccessToken, error) {
+	var token storage.AccessToken
+
+	sMap, err := kv.GetMap(ctx, b, []string{
+		join(keyPfxAT, axmName, keySfxATToken),
+		join(keyPfxAT, axmName, keySfxATValidity),
+		join(keyPfxAT, axmName, keySfxATExpiry),
+	})
+	if err != nil {
+		return token, fmt.Errorf("getting keys: %w", err)
+	}
+
+	token.Token = string(sMap[join(keyPfxAT, axmName, keySfxATToken)])
+
+	token.Validity, err = durationFromBytes(sMap[join(keyPfxAT, axmName, keySfxATValidity)])
+	if err != nil {
+		return token, fmt.Errorf("converting validity: %w", err)
+	}
+
+	token.Expiry, err = timeFromBytes(sMap[join(keyPfxAT, axmName, keySfxATExpiry)])
+	if err != nil {
+		return token, fmt.Errorf("converting expiry: %w", err)
+	}
+
+	return token, nil
+}
+
+func (s *KV) RetrieveAccessToken(ctx context.Context, axmName string) (storage.AccessToken, error) {
+	return retrieveAccessToken(ctx, axmName, s.b)
+}
+
+// GetOrRefreshAccessToken refreshes the OAuth 2 access token for axmName and stores it.
+func (s *KV) GetOrRefreshAccessToken(ctx context.Context, axmName string, refreshFunc func(ctx context.Context) (storage.AccessToken, error), due func(storage.AccessToken) bool) (token storage.AccessToken, err error) {
+	if axmName == "" {
+		return token, fmt.Errorf("%w: empty name", storage.ErrInvalidAXMName)
+	}
+	if refreshFunc == nil {
+		return token, errors.New("nil refresher")
+	}
+	if due == nil {
+		due = storage.AccessToken.Expired
+	}
+
+	token, err = retrieveAccessToken(ctx, axmName, s.b)
+	if err != nil && !errors.Is(err, kv.ErrKeyNotFound) {
+		return token, fmt.Errorf("retreive access token: %w", err)
+	}
+
+	if err == nil && token.Valid() && !due(token) {
+		return token, nil
+	}
+
+	token, err = refreshFunc(ctx)
+	if err != nil {
+		return token, fmt.Errorf("refreshing access token: %w", err)
+	}
+
+	if err = token.ValidError(); err != nil {
+		return token, fmt.Errorf("refreshed token invalid: %w", err)
+	}
+
+	err = storeAccessToken(ctx, axmName, s.b, token)
+	if err != nil {
+		return token, fmt.Errorf("storing access token: %w", err)
+	}
+
+	return token, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/micromdm/nanoaxm/storage"
 	"github.com/micromdm/nanolib/storage/kv"
@@ -17,8 +19,19 @@ const (
 	keySfxClientID = "cid"
 	keySfxKeyID    = "kid"
 	keySfxPrivKey  = "key"
+	keySfxScope    = "scp" // optional, absent for legacy records
 )
 
+// retrieveScope retrieves the optional scope for axmName.
+// An empty scope is returned if none is stored.
+func retrieveScope(ctx context.Context, axmName string, b kv.ROBucket) (string, error) {
+	scope, err := b.Get(ctx, join(keyPfxAC, axmName, keySfxScope))
+	if errors.Is(err, kv.ErrKeyNotFound) {
+		return "", nil
+	}
+	return string(scope), err
+}
+
 // RetrieveAuthCredential retrieves the auth crendetials from storage for axmName.
 // An error will be returned if axmName is invalid.
 func (s *KV) RetrieveAuthCredentials(ctx context.Context, axmName string) (storage.AuthCredentials, error) {
@@ -37,14 +50,21 @@ func (s *KV) RetrieveAuthCredentials(ctx context.Context, axmName string) (stora
 		return storage.AuthCredentials{}, err
 	}
 
+	scope, err := retrieveScope(ctx, axmName, s.b)
+	if err != nil {
+		return storage.AuthCredentials{}, fmt.Errorf("retrieving scope: %w", err)
+	}
+
 	return storage.AuthCredentials{
 		ClientID:      string(retMap[join(keyPfxAC, axmName, keySfxClientID)]),
 		KeyID:         string(retMap[join(keyPfxAC, axmName, keySfxKeyID)]),
 		PrivateKeyPEM: retMap[join(keyPfxAC, axmName, keySfxPrivKey)],
+		Scope:         scope,
 	}, nil
 }
 
 // StoreAuthCredentials stores the auth credentials to storage for axmName.
+// Any stored client assertion and access token for axmName are cleared.
 // An error will be returned if axmName is invalid.
 func (s *KV) StoreAuthCredentials(ctx context.Context, axmName string, ac storage.AuthCredentials) error {
 	if axmName == "" {
@@ -55,11 +75,84 @@ func (s *KV) StoreAuthCredentials(ctx context.Context, axmName string, ac storag
 	}
 
 	return kv.PerformCRUDBucketTxn(ctx, s.b, func(ctx context.Context, b kv.CRUDBucket) error {
-		return kv.SetMap(ctx, b, map[string][]byte{
+		err := kv.SetMap(ctx, b, map[string][]byte{
 			join(keyPfxAC, axmName, keySfxClientID): []byte(ac.ClientID),
 			join(keyPfxAC, axmName, keySfxKeyID):    []byte(ac.KeyID),
 			join(keyPfxAC, axmName, keySfxPrivKey):  []byte(ac.PrivateKeyPEM),
 			join(keyPfxName, axmName):               []byte(valOne),
 		})
+		if err != nil {
+			return err
+		}
+
+		if ac.Scope != "" {
+			err = b.Set(ctx, join(keyPfxAC, axmName, keySfxScope), []byte(ac.Scope))
+		} else {
+			err = b.Delete(ctx, join(keyPfxAC, axmName, keySfxScope))
+		}
+		if err != nil {
+			return fmt.Errorf("scope: %w", err)
+		}
+
+		// tokens generated with the previous credentials are no longer valid
+		return kv.DeleteSlice(ctx, b, []string{
+			join(keyPfxCA, axmName, keySfxCAToken),
+			join(keyPfxCA, axmName, keySfxCAValidity),
+			join(keyPfxCA, axmName, keySfxCAExpiry),
+			join(keyPfxAT, axmName, keySfxATToken),
+			join(keyPfxAT, axmName, keySfxATValidity),
+			join(keyPfxAT, axmName, keySfxATExpiry),
+		})
+	})
+}
+
+// ListAXMNames returns the sorted AxM names with stored auth credentials.
+// The key-value store must support traversing keys by prefix.
+func (s *KV) ListAXMNames(ctx context.Context) ([]string, error) {
+	t, ok := s.b.(kv.KeysPrefixTraverser)
+	if !ok {
+		return nil, errors.New("key-value store does not support key traversal")
+	}
+
+	var names []string
+	prefix := keyPfxName + keySep
+	for k := range t.KeysPrefix(ctx, prefix, nil) {
+		names = append(names, strings.TrimPrefix(k, prefix))
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// DeleteAuthCredentials deletes the auth credentials for axmName
+// including any stored client assertion and access token.
+// An error will be returned if axmName is invalid.
+func (s *KV) DeleteAuthCredentials(ctx context.Context, axmName string) error {
+	if axmName == "" {
+		return fmt.Errorf("%w: empty name", storage.ErrInvalidAXMName)
+	}
+
+	return kv.PerformCRUDBucketTxn(ctx, s.b, func(ctx context.Context, b kv.CRUDBucket) error {
+		found, err := b.Has(ctx, join(keyPfxName, axmName))
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("%w: not found: %s", storage.ErrInvalidAXMName, axmName)
+		}
+
+		return kv.DeleteSlice(ctx, b, []string{
+			join(keyPfxName, axmName),
+			join(keyPfxAC, axmName, keySfxClientID),
+			join(keyPfxAC, axmName, keySfxKeyID),
+			join(keyPfxAC, axmName, keySfxPrivKey),
+			join(keyPfxAC, axmName, keySfxScope),
+			join(keyPfxCA, axmName, keySfxCAToken),
+			join(keyPfxCA, axmName, keySfxCAValidity),
+			join(keyPfxCA, axmName, keySfxCAExpiry),
+			join(keyPfxAT, axmName, keySfxATToken),
+			join(keyPfxAT, axmName, keySfxATValidity),
+			join(keyPfxAT, axmName, keySfxATExpiry),
+		})
 	})
 }
@@ -47,6 +47,11 @@ func retrieveClientAssertion(ctx context.Context, axmName string, b kv.ROBucket)
 	token.Token = string(sMap[join(keyPfxCA, axmName, keySfxCAToken)])
 	token.ClientID = string(sMap[join(keyPfxAC, axmName, keySfxClientID)])
 
+	token.Scope, err = retrieveScope(ctx, axmName, b)
+	if err != nil {
+		return token, fmt.Errorf("retrieving scope: %w", err)
+	}
+
 	token.Validity, err = durationFromBytes(sMap[join(keyPfxCA, axmName, keySfxCAValidity)])
 	if err != nil {
 		return token, fmt.Errorf("converting validity: %w", err)
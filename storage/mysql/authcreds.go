@@ -22,17 +22,19 @@ func (s *MySQLStorage) RetrieveAuthCredentials(ctx context.Context, axmName stri
 	if errors.Is(err, sql.ErrNoRows) {
 		return ac, fmt.Errorf("%v: %w", err, storage.ErrInvalidAXMName)
 	} else if err != nil {
-		return ac, err
+		return ac, schemaErr(err)
 	}
 
 	ac.ClientID = dbac.ClientID
 	ac.KeyID = dbac.KeyID
 	ac.PrivateKeyPEM = dbac.PrivKeyPem
+	ac.Scope = dbac.Scope.String
 
 	return ac, nil
 }
 
 // StoreAuthCredentials stores the auth credentials to storage for axmName.
+// Any stored client assertion and access token for axmName are cleared.
 // An error will be returned if axmName is invalid.
 func (s *MySQLStorage) StoreAuthCredentials(ctx context.Context, axmName string, ac storage.AuthCredentials) error {
 	if axmName == "" {
@@ -42,21 +44,58 @@ func (s *MySQLStorage) StoreAuthCredentials(ctx context.Context, axmName string,
 		return fmt.Errorf("auth creds invalid: %s: %w", axmName, err)
 	}
 
+	privKeyPEM := ac.PrivateKeyPEM
+	if privKeyPEM == nil {
+		// the private key is optional but the column is not nullable
+		privKeyPEM = []byte{}
+	}
+
 	// raw SQL (vs. sqlc) due to https://github.com/sqlc-dev/sqlc/issues/2789
 	_, err := s.db.ExecContext(
 		ctx, `
 INSERT INTO axm_names 
-	(name, client_id, key_id, priv_key_pem)
+	(name, client_id, key_id, priv_key_pem, scope)
 VALUES 
-	(?, ?, ?, ?) as new
+	(?, ?, ?, ?, ?) as new
 ON DUPLICATE KEY UPDATE 
 	client_id = new.client_id,
 	key_id = new.key_id,
-	priv_key_pem = new.priv_key_pem;`,
+	priv_key_pem = new.priv_key_pem,
+	scope = new.scope,
+	ca_token = NULL,
+	ca_validity_sec = NULL,
+	ca_expiry_unix = NULL,
+	at_token = NULL,
+	at_validity_sec = NULL,
+	at_expiry_unix = NULL;`,
 		axmName,
 		ac.ClientID,
 		ac.KeyID,
-		ac.PrivateKeyPEM,
+		privKeyPEM,
+		sql.NullString{String: ac.Scope, Valid: ac.Scope != ""},
 	)
-	return err
+	return schemaErr(err)
+}
+
+// ListAXMNames returns the sorted AxM names with stored auth credentials.
+func (s *MySQLStorage) ListAXMNames(ctx context.Context) ([]string, error) {
+	return s.q.ListAXMNames(ctx)
+}
+
+// DeleteAuthCredentials deletes the auth credentials for axmName
+// including any stored client assertion and access token.
+// An error will be returned if axmName is invalid.
+func (s *MySQLStorage) DeleteAuthCredentials(ctx context.Context, axmName string) error {
+	if axmName == "" {
+		return fmt.Errorf("%w: empty name", storage.ErrInvalidAXMName)
+	}
+
+	n, err := s.q.DeleteAuthCredentials(ctx, axmName)
+	if err != nil {
+		return err
+	}
+	if n < 1 {
+		return fmt.Errorf("%w: not found: %s", storage.ErrInvalidAXMName, axmName)
+	}
+	return nil
 }
@@ -17,6 +17,7 @@ func dbcaToCA(dbca sqlc.RetrieveClientAssertionRow) storage.ClientAssertion {
 		Validity: time.Duration(dbca.CaValiditySec.Int32) * time.Second,
 		Expiry:   time.Unix(int64(dbca.CaExpiryUnix.Int32), 0),
 		ClientID: dbca.ClientID,
+		Scope:    dbca.Scope.String,
 	}
 }
 
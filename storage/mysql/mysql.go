@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	_ "embed"
 	"fmt"
+	"strings"
 
 	"github.com/micromdm/nanoaxm/storage/mysql/sqlc"
 )
@@ -70,6 +71,17 @@ func New(opts ...Option) (*MySQLStorage, error) {
 	return &MySQLStorage{db: cfg.db, q: sqlc.New(cfg.db)}, nil
 }
 
+// schemaErr wraps err with a hint to upgrade the table if err is
+// a MySQL "Unknown column" error (i.e. error 1054). This is usually
+// caused by a table created with an earlier schema.
+// The error text is checked so that any MySQL driver may be used.
+func schemaErr(err error) error {
+	if err != nil && strings.Contains(err.Error(), "Unknown column") {
+		return fmt.Errorf("%w: table schema out of date: apply storage/mysql/upgrade.sql", err)
+	}
+	return err
+}
+
 // const timestampFormat = "2006-01-02 15:04:05"
 
 // tx wraps g in transactions using db.
@@ -80,6 +92,7 @@ func tx(ctx context.Context, db *sql.DB, q *sqlc.Queries, g func(ctx context.Con
 		return fmt.Errorf("tx begin: %w", err)
 	}
 	if err = g(ctx, tx, q.WithTx(tx)); err != nil {
+		err = schemaErr(err)
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return fmt.Errorf("tx rollback: %w; while trying to handle error: %v", rbErr, err)
 		}
@@ -2,10 +2,12 @@ package mysql
 
 import (
 	"context"
+	"errors"
 	"os"
+	"strings"
 	"testing"
 
-	_ "github.com/go-sql-driver/mysql"
+	mysqldrv "github.com/go-sql-driver/mysql"
 	"github.com/micromdm/nanoaxm/storage"
 	"github.com/micromdm/nanoaxm/storage/test"
 )
@@ -28,3 +30,23 @@ func TestMySQLStorage(t *testing.T) {
 
 	test.TestStorage(t, context.Background(), s, gca)
 }
+
+func TestSchemaErr(t *testing.T) {
+	if err := schemaErr(nil); err != nil {
+		t.Errorf("expected nil error, got: %v", err)
+	}
+
+	other := errors.New("other error")
+	if err := schemaErr(other); err != other {
+		t.Errorf("expected unwrapped error, got: %v", err)
+	}
+
+	colErr := &mysqldrv.MySQLError{Number: 1054, SQLState: [5]byte{'4', '2', 'S', '2', '2'}, Message: "Unknown column 'at_token' in 'field list'"}
+	err := schemaErr(colErr)
+	if !errors.Is(err, colErr) {
+		t.Errorf("expected wrapped error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "upgrade.sql") {
+		t.Errorf("expected upgrade hint, got: %v", err)
+	}
+}
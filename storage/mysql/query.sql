@@ -1,8 +1,20 @@
 -- name: RetrieveAuthCredentials :one
-SELECT key_id, client_id, priv_key_pem FROM axm_names WHERE name = ?;
+SELECT key_id, client_id, priv_key_pem, scope FROM axm_names WHERE name = ?;
 
 -- name: RetrieveClientAssertion :one
-SELECT ca_token, ca_validity_sec, ca_expiry_unix, client_id FROM axm_names WHERE name = ? FOR UPDATE;
+SELECT ca_token, ca_validity_sec, ca_expiry_unix, client_id, scope FROM axm_names WHERE name = ? FOR UPDATE;
 
 -- name: UpdateClientAssertion :exec
-UPDATE axm_names SET ca_token = ?, ca_validity_sec = ?, ca_expiry_unix = ? WHERE name = ?;
\ No newline at end of file
+UPDATE axm_names SET ca_token = ?, ca_validity_sec = ?, ca_expiry_unix = ? WHERE name = ?;
+
+-- name: RetrieveAccessToken :one
+SELECT at_token, at_validity_sec, at_expiry_unix FROM axm_names WHERE name = ? FOR UPDATE;
+
+-- name: UpdateAccessToken :exec
+UPDATE axm_names SET at_token = ?, at_validity_sec = ?, at_expiry_unix = ? WHERE name = ?;
+
+-- name: ListAXMNames :many
+SELECT name FROM axm_names ORDER BY name;
+
+-- name: DeleteAuthCredentials :execrows
+DELETE FROM axm_names WHERE name = ?;
\ No newline at end of file
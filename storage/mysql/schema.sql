@@ -4,11 +4,16 @@ CREATE TABLE axm_names (
     key_id       VARCHAR(255) NOT NULL,
     client_id    VARCHAR(255) NOT NULL,
     priv_key_pem TEXT         NOT NULL,
+    scope        VARCHAR(255) NULL, -- OAuth 2 scope; NULL for legacy (client ID prefix)
 
     ca_token        TEXT NULL,
     ca_validity_sec INT  NULL, -- validity in seconds
     ca_expiry_unix  INT  NULL, -- unix timestamp
 
+    at_token        TEXT NULL,
+    at_validity_sec INT  NULL, -- validity in seconds
+    at_expiry_unix  INT  NULL, -- unix timestamp
+
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
 
@@ -13,9 +13,13 @@ type AxmName struct {
 	KeyID         string
 	ClientID      string
 	PrivKeyPem    []byte
+	Scope         sql.NullString
 	CaToken       sql.NullString
 	CaValiditySec sql.NullInt32
 	CaExpiryUnix  sql.NullInt32
+	AtToken       sql.NullString
+	AtValiditySec sql.NullInt32
+	AtExpiryUnix  sql.NullInt32
 	CreatedAt     sql.NullTime
 	UpdatedAt     sql.NullTime
 }
@@ -11,24 +11,30 @@ import (
 )
 
 const retrieveAuthCredentials = `-- name: RetrieveAuthCredentials :one
-SELECT key_id, client_id, priv_key_pem FROM axm_names WHERE name = ?
+SELECT key_id, client_id, priv_key_pem, scope FROM axm_names WHERE name = ?
 `
 
 type RetrieveAuthCredentialsRow struct {
 	KeyID      string
 	ClientID   string
 	PrivKeyPem []byte
+	Scope      sql.NullString
 }
 
 func (q *Queries) RetrieveAuthCredentials(ctx context.Context, name string) (RetrieveAuthCredentialsRow, error) {
 	row := q.db.QueryRowContext(ctx, retrieveAuthCredentials, name)
 	var i RetrieveAuthCredentialsRow
-	err := row.Scan(&i.KeyID, &i.ClientID, &i.PrivKeyPem)
+	err := row.Scan(
+		&i.KeyID,
+		&i.ClientID,
+		&i.PrivKeyPem,
+		&i.Scope,
+	)
 	return i, err
 }
 
 const retrieveClientAssertion = `-- name: RetrieveClientAssertion :one
-SELECT ca_token, ca_validity_sec, ca_expiry_unix, client_id FROM axm_names WHERE name = ? FOR UPDATE
+SELECT ca_token, ca_validity_sec, ca_expiry_unix, client_id, scope FROM axm_names WHERE name = ? FOR UPDATE
 `
 
 type RetrieveClientAssertionRow struct {
@@ -36,6 +42,7 @@ type RetrieveClientAssertionRow struct {
 	CaValiditySec sql.NullInt32
 	CaExpiryUnix  sql.NullInt32
 	ClientID      string
+	Scope         sql.NullString
 }
 
 func (q *Queries) RetrieveClientAssertion(ctx context.Context, name string) (RetrieveClientAssertionRow, error) {
@@ -46,6 +53,7 @@ func (q *Queries) RetrieveClientAssertion(ctx context.Context, name string) (Ret
 		&i.CaValiditySec,
 		&i.CaExpiryUnix,
 		&i.ClientID,
+		&i.Scope,
 	)
 	return i, err
 }
@@ -70,3 +78,80 @@ func (q *Queries) UpdateClientAssertion(ctx context.Context, arg UpdateClientAss
 	)
 	return err
 }
+
+const retrieveAccessToken = `-- name: RetrieveAccessToken :one
+SELECT at_token, at_validity_sec, at_expiry_unix FROM axm_names WHERE name = ? FOR UPDATE
+`
+
+type RetrieveAccessTokenRow struct {
+	AtToken       sql.NullString
+	AtValiditySec sql.NullInt32
+	AtExpiryUnix  sql.NullInt32
+}
+
+func (q *Queries) RetrieveAccessToken(ctx context.Context, name string) (RetrieveAccessTokenRow, error) {
+	row := q.db.QueryRowContext(ctx, retrieveAccessToken, name)
+	var i RetrieveAccessTokenRow
+	err := row.Scan(&i.AtToken, &i.AtValiditySec, &i.AtExpiryUnix)
+	return i, err
+}
+
+const updateAccessToken = `-- name: UpdateAccessToken :exec
+UPDATE axm_names SET at_token = ?, at_validity_sec = ?, at_expiry_unix = ? WHERE name = ?
+`
+
+type UpdateAccessTokenParams struct {
+	AtToken       sql.NullString
+	AtValiditySec sql.NullInt32
+	AtExpiryUnix  sql.NullInt32
+	Name          string
+}
+
+func (q *Queries) UpdateAccessToken(ctx context.Context, arg UpdateAccessTokenParams) error {
+	_, err := q.db.ExecContext(ctx, updateAccessToken,
+		arg.AtToken,
+		arg.AtValiditySec,
+		arg.AtExpiryUnix,
+		arg.Name,
+	)
+	return err
+}
+
+const listAXMNames = `-- name: ListAXMNames :many
+SELECT name FROM axm_names ORDER BY name
+`
+
+func (q *Queries) ListAXMNames(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listAXMNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteAuthCredentials = `-- name: DeleteAuthCredentials :execrows
+DELETE FROM axm_names WHERE name = ?
+`
+
+func (q *Queries) DeleteAuthCredentials(ctx context.Context, name string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAuthCredentials, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,42 @@
+-- Upgrades the axm_names table created by earlier NanoAXM versions to
+-- the current schema.sql. Each column is only added if it is missing so
+-- this file may be run against any existing (or already upgraded) table.
+
+-- OAuth 2 scope column. Required for storing auth credentials.
+SET @stmt = (SELECT IF(COUNT(*) = 0,
+    'ALTER TABLE axm_names ADD COLUMN scope VARCHAR(255) NULL AFTER priv_key_pem',
+    'DO 0')
+    FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'axm_names' AND column_name = 'scope');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+-- Access token columns. Required as storing auth credentials clears
+-- any stored access token (even if access tokens are not shared).
+SET @stmt = (SELECT IF(COUNT(*) = 0,
+    'ALTER TABLE axm_names ADD COLUMN at_token TEXT NULL AFTER ca_expiry_unix',
+    'DO 0')
+    FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'axm_names' AND column_name = 'at_token');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = (SELECT IF(COUNT(*) = 0,
+    'ALTER TABLE axm_names ADD COLUMN at_validity_sec INT NULL AFTER at_token',
+    'DO 0')
+    FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'axm_names' AND column_name = 'at_validity_sec');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = (SELECT IF(COUNT(*) = 0,
+    'ALTER TABLE axm_names ADD COLUMN at_expiry_unix INT NULL AFTER at_validity_sec',
+    'DO 0')
+    FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'axm_names' AND column_name = 'at_expiry_unix');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrDeleteNotSupported is returned when deleting auth credentials from
+// a storage backend that does not implement [AuthCredentialsDeleter].
+var ErrDeleteNotSupported = errors.New("deleting auth creds not supported")
+
+// AuthCredentialsStoreNotifier wraps an AuthCredentialsStorer to notify
+// when auth credentials change (are stored or deleted).
+// For example to invalidate cached tokens (see client.Transport.Invalidate).
+type AuthCredentialsStoreNotifier struct {
+	AuthCredentialsStorer
+	notifyFns []func(axmName string)
+}
+
+// NewAuthCredentialsStoreNotifier creates a new notifier which calls
+// each of notifyFns after auth credentials are successfully stored in
+// (or deleted from) s.
+func NewAuthCredentialsStoreNotifier(s AuthCredentialsStorer, notifyFns ...func(axmName string)) *AuthCredentialsStoreNotifier {
+	return &AuthCredentialsStoreNotifier{AuthCredentialsStorer: s, notifyFns: notifyFns}
+}
+
+// StoreAuthCredentials stores the auth credentials to the wrapped storage
+// for axmName and then notifies of the change.
+func (s *AuthCredentialsStoreNotifier) StoreAuthCredentials(ctx context.Context, axmName string, ac AuthCredentials) error {
+	if err := s.AuthCredentialsStorer.StoreAuthCredentials(ctx, axmName, ac); err != nil {
+		return err
+	}
+	for _, fn := range s.notifyFns {
+		fn(axmName)
+	}
+	return nil
+}
+
+// DeleteAuthCredentials deletes the auth credentials for axmName from
+// the wrapped storage and then notifies of the change.
+// [ErrDeleteNotSupported] is returned if the wrapped storage does not
+// implement [AuthCredentialsDeleter].
+func (s *AuthCredentialsStoreNotifier) DeleteAuthCredentials(ctx context.Context, axmName string) error {
+	d, ok := s.AuthCredentialsStorer.(AuthCredentialsDeleter)
+	if !ok {
+		return ErrDeleteNotSupported
+	}
+	if err := d.DeleteAuthCredentials(ctx, axmName); err != nil {
+		return err
+	}
+	for _, fn := range s.notifyFns {
+		fn(axmName)
+	}
+	return nil
+}
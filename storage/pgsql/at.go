@@ -0,0 +1,72 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/micromdm/nanoaxm/storage"
+	"github.com/micromdm/nanoaxm/storage/pgsql/sqlc"
+)
+
+func dbatToAT(dbat sqlc.RetrieveAccessTokenRow) storage.AccessToken {
+	at := storage.AccessToken{
+		Token:    dbat.AtToken.String,
+		Validity: time.Duration(dbat.AtValiditySec.Int32) * time.Second,
+	}
+	if dbat.AtExpiryUnix.Valid {
+		at.Expiry = time.Unix(dbat.AtExpiryUnix.Int64, 0)
+	}
+	return at
+}
+
+// GetOrRefreshAccessToken refreshes the OAuth 2 access token for axmName and stores it.
+func (s *PgSQLStorage) GetOrRefreshAccessToken(ctx context.Context, axmName string, refreshFunc func(ctx context.Context) (storage.AccessToken, error), due func(storage.AccessToken) bool) (token storage.AccessToken, err error) {
+	if axmName == "" {
+		return token, fmt.Errorf("%w: empty name", storage.ErrInvalidAXMName)
+	}
+	if refreshFunc == nil {
+		return token, errors.New("nil refresher")
+	}
+	if due == nil {
+		due = storage.AccessToken.Expired
+	}
+
+	return token, tx(ctx, s.db, s.q, func(ctx context.Context, tx *sql.Tx, qtx *sqlc.Queries) error {
+		// always select the row to lock it even if we're refreshing
+		dbat, err := qtx.RetrieveAccessToken(ctx, axmName)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%v: %w", err, storage.ErrInvalidAXMName)
+		} else if err != nil {
+			return err
+		}
+
+		token = dbatToAT(dbat)
+		if token.Valid() && !due(token) {
+			return nil
+		}
+
+		token, err = refreshFunc(ctx)
+		if err != nil {
+			return fmt.Errorf("refreshing access token: %w", err)
+		}
+
+		if err = token.ValidError(); err != nil {
+			return fmt.Errorf("refreshed token invalid: %w", err)
+		}
+
+		err = qtx.UpdateAccessToken(ctx, sqlc.UpdateAccessTokenParams{
+			AtToken:       sql.NullString{String: token.Token, Valid: true},
+			AtValiditySec: sql.NullInt32{Int32: int32(token.Validity.Seconds()), Valid: true},
+			AtExpiryUnix:  sql.NullInt64{Int64: token.Expiry.Unix(), Valid: true},
+			Name:          axmName,
+		})
+		if err != nil {
+			return fmt.Errorf("storing access token: %w", err)
+		}
+
+		return nil
+	})
+}
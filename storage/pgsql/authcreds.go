@@ -0,0 +1,90 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/micromdm/nanoaxm/storage"
+	"github.com/micromdm/nanoaxm/storage/pgsql/sqlc"
+)
+
+// dbacToAC converts the database auth credentials to storage auth credentials.
+func dbacToAC(dbac sqlc.RetrieveAuthCredentialsRow) storage.AuthCredentials {
+	return storage.AuthCredentials{
+		ClientID:      dbac.ClientID,
+		KeyID:         dbac.KeyID,
+		PrivateKeyPEM: dbac.PrivKeyPem,
+		Scope:         dbac.Scope.String,
+	}
+}
+
+// retrieveAuthCredentials retrieves the auth credentials for axmName using q.
+func retrieveAuthCredentials(ctx context.Context, q *sqlc.Queries, axmName string) (storage.AuthCredentials, error) {
+	dbac, err := q.RetrieveAuthCredentials(ctx, axmName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.AuthCredentials{}, fmt.Errorf("%v: %w", err, storage.ErrInvalidAXMName)
+	} else if err != nil {
+		return storage.AuthCredentials{}, err
+	}
+	return dbacToAC(dbac), nil
+}
+
+// RetrieveAuthCredential retrieves the auth crendetials from storage for axmName.
+// An error will be returned if axmName is invalid.
+func (s *PgSQLStorage) RetrieveAuthCredentials(ctx context.Context, axmName string) (storage.AuthCredentials, error) {
+	if axmName == "" {
+		return storage.AuthCredentials{}, fmt.Errorf("%w: empty name", storage.ErrInvalidAXMName)
+	}
+	return retrieveAuthCredentials(ctx, s.q, axmName)
+}
+
+// StoreAuthCredentials stores the auth credentials to storage for axmName.
+// Any stored client assertion and access token for axmName are cleared.
+// An error will be returned if axmName is invalid.
+func (s *PgSQLStorage) StoreAuthCredentials(ctx context.Context, axmName string, ac storage.AuthCredentials) error {
+	if axmName == "" {
+		return fmt.Errorf("%w: empty name", storage.ErrInvalidAXMName)
+	}
+	if err := ac.ValidError(); err != nil {
+		return fmt.Errorf("auth creds invalid: %s: %w", axmName, err)
+	}
+
+	privKeyPEM := ac.PrivateKeyPEM
+	if privKeyPEM == nil {
+		// the private key is optional but the column is not nullable
+		privKeyPEM = []byte{}
+	}
+
+	return s.q.StoreAuthCredentials(ctx, sqlc.StoreAuthCredentialsParams{
+		Name:       axmName,
+		ClientID:   ac.ClientID,
+		KeyID:      ac.KeyID,
+		PrivKeyPem: privKeyPEM,
+		Scope:      sql.NullString{String: ac.Scope, Valid: ac.Scope != ""},
+	})
+}
+
+// ListAXMNames returns the sorted AxM names with stored auth credentials.
+func (s *PgSQLStorage) ListAXMNames(ctx context.Context) ([]string, error) {
+	return s.q.ListAXMNames(ctx)
+}
+
+// DeleteAuthCredentials deletes the auth credentials for axmName
+// including any stored client assertion and access token.
+// An error will be returned if axmName is invalid.
+func (s *PgSQLStorage) DeleteAuthCredentials(ctx context.Context, axmName string) error {
+	if axmName == "" {
+		return fmt.Errorf("%w: empty name", storage.ErrInvalidAXMName)
+	}
+
+	n, err := s.q.DeleteAuthCredentials(ctx, axmName)
+	if err != nil {
+		return err
+	}
+	if n < 1 {
+		return fmt.Errorf("%w: not found: %s", storage.ErrInvalidAXMName, axmName)
+	}
+	return nil
+}
@@ -0,0 +1,3 @@
+package pgsql
+
+//go:generate sqlc generate
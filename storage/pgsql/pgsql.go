@@ -0,0 +1,89 @@
+// Package pgsql implements a NanoAXM storage backend using PostgreSQL.
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/micromdm/nanoaxm/storage/pgsql/sqlc"
+)
+
+// PgSQLStorage implements a storage.AllStorage using PostgreSQL.
+type PgSQLStorage struct {
+	db *sql.DB
+	q  *sqlc.Queries
+}
+
+type config struct {
+	driver string
+	dsn    string
+	db     *sql.DB
+}
+
+// Option allows configuring a PgSQLStorage.
+type Option func(*config)
+
+// WithDSN sets the storage PostgreSQL data source name.
+func WithDSN(dsn string) Option {
+	return func(c *config) {
+		c.dsn = dsn
+	}
+}
+
+// WithDriver sets a custom PostgreSQL driver for the storage.
+//
+// Default driver is "postgres".
+// Value is ignored if WithDB is used.
+func WithDriver(driver string) Option {
+	return func(c *config) {
+		c.driver = driver
+	}
+}
+
+// WithDB sets a custom PostgreSQL *sql.DB to the storage.
+//
+// If set, driver passed via WithDriver is ignored.
+func WithDB(db *sql.DB) Option {
+	return func(c *config) {
+		c.db = db
+	}
+}
+
+// New creates and returns a new PgSQLStorage.
+func New(opts ...Option) (*PgSQLStorage, error) {
+	cfg := &config{driver: "postgres"}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	var err error
+	if cfg.db == nil {
+		cfg.db, err = sql.Open(cfg.driver, cfg.dsn)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = cfg.db.Ping(); err != nil {
+		return nil, err
+	}
+	return &PgSQLStorage{db: cfg.db, q: sqlc.New(cfg.db)}, nil
+}
+
+// tx wraps g in transactions using db.
+// If g returns an err the transaction will be rolled back; otherwise committed.
+func tx(ctx context.Context, db *sql.DB, q *sqlc.Queries, g func(ctx context.Context, tx *sql.Tx, qtx *sqlc.Queries) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("tx begin: %w", err)
+	}
+	if err = g(ctx, tx, q.WithTx(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx rollback: %w; while trying to handle error: %v", rbErr, err)
+		}
+		return fmt.Errorf("tx rolled back: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package pgsql
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/micromdm/nanoaxm/storage"
+	"github.com/micromdm/nanoaxm/storage/test"
+)
+
+func TestPgSQLStorage(t *testing.T) {
+	testDSN := os.Getenv("NANOAXM_PGSQL_STORAGE_TEST_DSN")
+	if testDSN == "" {
+		t.Skip("NANOAXM_PGSQL_STORAGE_TEST_DSN is empty")
+	}
+
+	s, err := New(WithDSN(testDSN))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gca := func(ctx context.Context, axmName string) (storage.ClientAssertion, error) {
+		dbca, err := s.q.RetrieveClientAssertion(ctx, axmName)
+		return dbcaToCA(dbca), err
+	}
+
+	test.TestStorage(t, context.Background(), s, gca)
+}
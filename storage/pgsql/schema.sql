@@ -0,0 +1,21 @@
+CREATE TABLE axm_names (
+    name VARCHAR(255) NOT NULL,
+
+    key_id       VARCHAR(255) NOT NULL,
+    client_id    VARCHAR(255) NOT NULL,
+    priv_key_pem BYTEA        NOT NULL,
+    scope        VARCHAR(255) NULL, -- OAuth 2 scope; NULL for legacy (client ID prefix)
+
+    ca_token        TEXT   NULL,
+    ca_validity_sec INT    NULL, -- validity in seconds
+    ca_expiry_unix  BIGINT NULL, -- unix timestamp
+
+    at_token        TEXT   NULL,
+    at_validity_sec INT    NULL, -- validity in seconds
+    at_expiry_unix  BIGINT NULL, -- unix timestamp
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (name)
+);
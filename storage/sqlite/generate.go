@@ -0,0 +1,3 @@
+package sqlite
+
+//go:generate sqlc generate
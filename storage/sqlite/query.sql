@@ -0,0 +1,38 @@
+-- name: RetrieveAuthCredentials :one
+SELECT key_id, client_id, priv_key_pem, scope FROM axm_names WHERE name = ?;
+
+-- name: StoreAuthCredentials :exec
+INSERT INTO axm_names
+    (name, client_id, key_id, priv_key_pem, scope)
+VALUES
+    (?, ?, ?, ?, ?)
+ON CONFLICT (name) DO UPDATE SET
+    client_id = EXCLUDED.client_id,
+    key_id = EXCLUDED.key_id,
+    priv_key_pem = EXCLUDED.priv_key_pem,
+    scope = EXCLUDED.scope,
+    ca_token = NULL,
+    ca_validity_sec = NULL,
+    ca_expiry_unix = NULL,
+    at_token = NULL,
+    at_validity_sec = NULL,
+    at_expiry_unix = NULL,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: RetrieveClientAssertion :one
+SELECT ca_token, ca_validity_sec, ca_expiry_unix, client_id, scope FROM axm_names WHERE name = ?;
+
+-- name: UpdateClientAssertion :exec
+UPDATE axm_names SET ca_token = ?, ca_validity_sec = ?, ca_expiry_unix = ?, updated_at = CURRENT_TIMESTAMP WHERE name = ?;
+
+-- name: RetrieveAccessToken :one
+SELECT at_token, at_validity_sec, at_expiry_unix FROM axm_names WHERE name = ?;
+
+-- name: UpdateAccessToken :exec
+UPDATE axm_names SET at_token = ?, at_validity_sec = ?, at_expiry_unix = ?, updated_at = CURRENT_TIMESTAMP WHERE name = ?;
+
+-- name: ListAXMNames :many
+SELECT name FROM axm_names ORDER BY name;
+
+-- name: DeleteAuthCredentials :execrows
+DELETE FROM axm_names WHERE name = ?;
\ No newline at end of file
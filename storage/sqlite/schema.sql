@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS axm_names (
+    name TEXT NOT NULL,
+
+    key_id       TEXT NOT NULL,
+    client_id    TEXT NOT NULL,
+    priv_key_pem BLOB NOT NULL,
+    scope        TEXT NULL, -- OAuth 2 scope; NULL for legacy (client ID prefix)
+
+    ca_token        TEXT    NULL,
+    ca_validity_sec INTEGER NULL, -- validity in seconds
+    ca_expiry_unix  INTEGER NULL, -- unix timestamp
+
+    at_token        TEXT    NULL,
+    at_validity_sec INTEGER NULL, -- validity in seconds
+    at_expiry_unix  INTEGER NULL, -- unix timestamp
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (name)
+);
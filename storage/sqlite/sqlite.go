@@ -0,0 +1,121 @@
+// Package sqlite implements a NanoAXM storage backend using SQLite.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	_ "embed"
+	"fmt"
+
+	"github.com/micromdm/nanoaxm/storage/sqlite/sqlc"
+)
+
+//go:embed schema.sql
+var schema string
+
+// SQLiteStorage implements a storage.AllStorage using SQLite.
+type SQLiteStorage struct {
+	db *sql.DB
+	q  *sqlc.Queries
+}
+
+type config struct {
+	driver string
+	dsn    string
+	db     *sql.DB
+}
+
+// Option allows configuring a SQLiteStorage.
+type Option func(*config)
+
+// WithDSN sets the storage SQLite data source name.
+// For example a file path or "file:" URI with driver-specific parameters.
+func WithDSN(dsn string) Option {
+	return func(c *config) {
+		c.dsn = dsn
+	}
+}
+
+// WithDriver sets a custom SQLite driver for the storage.
+//
+// Default driver is "sqlite".
+// Value is ignored if WithDB is used.
+func WithDriver(driver string) Option {
+	return func(c *config) {
+		c.driver = driver
+	}
+}
+
+// WithDB sets a custom SQLite *sql.DB to the storage.
+//
+// If set, driver passed via WithDriver is ignored.
+func WithDB(db *sql.DB) Option {
+	return func(c *config) {
+		c.db = db
+	}
+}
+
+// New creates and returns a new SQLiteStorage.
+// The storage tables are created if they do not exist.
+func New(opts ...Option) (*SQLiteStorage, error) {
+	cfg := &config{driver: "sqlite"}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	var err error
+	if cfg.db == nil {
+		cfg.db, err = sql.Open(cfg.driver, cfg.dsn)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = cfg.db.Ping(); err != nil {
+		return nil, err
+	}
+	if _, err = cfg.db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("creating schema: %w", err)
+	}
+	return &SQLiteStorage{db: cfg.db, q: sqlc.New(cfg.db)}, nil
+}
+
+// tx wraps g in a transaction using a connection from db.
+// If g returns an err the transaction will be rolled back; otherwise committed.
+// SQLite has no row locking so the transaction is started with the
+// database write lock held (i.e. "BEGIN IMMEDIATE") which serializes
+// transactions even between processes.
+func tx(ctx context.Context, db *sql.DB, g func(ctx context.Context, qtx *sqlc.Queries) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("tx conn: %w", err)
+	}
+	defer conn.Close()
+	if _, err = conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return fmt.Errorf("tx begin: %w", err)
+	}
+	if err = g(ctx, sqlc.New(conn)); err != nil {
+		if rbErr := rollback(conn); rbErr != nil {
+			return fmt.Errorf("tx rollback: %w; while trying to handle error: %v", rbErr, err)
+		}
+		return fmt.Errorf("tx rolled back: %w", err)
+	}
+	if _, err = conn.ExecContext(ctx, "COMMIT"); err != nil {
+		if rbErr := rollback(conn); rbErr != nil {
+			return fmt.Errorf("tx rollback: %w; while trying to handle commit error: %v", rbErr, err)
+		}
+		return fmt.Errorf("tx commit: %w", err)
+	}
+	return nil
+}
+
+// rollback rolls back the transaction on conn even if the context is done.
+// If that fails the connection is discarded rather than returned to
+// the pool as it may still be in the transaction (holding the lock).
+func rollback(conn *sql.Conn) error {
+	_, err := conn.ExecContext(context.Background(), "ROLLBACK")
+	if err != nil {
+		// database/sql discards connections that report being bad
+		conn.Raw(func(any) error { return driver.ErrBadConn })
+	}
+	return err
+}
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/micromdm/nanoaxm/storage"
+	"github.com/micromdm/nanoaxm/storage/test"
+	_ "modernc.org/sqlite"
+)
+
+func TestSQLiteStorage(t *testing.T) {
+	dsn := "file:" + filepath.Join(t.TempDir(), "nanoaxm.db") + "?_pragma=busy_timeout(5000)"
+
+	s, err := New(WithDSN(dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.db.Close()
+
+	gca := func(ctx context.Context, axmName string) (storage.ClientAssertion, error) {
+		dbca, err := s.q.RetrieveClientAssertion(ctx, axmName)
+		return dbcaToCA(dbca), err
+	}
+
+	test.TestStorage(t, context.Background(), s, gca)
+
+	// creating the schema again must not fail
+	s2, err := New(WithDSN(dsn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2.db.Close()
+}
+
+func TestSQLiteConcurrentRefresh(t *testing.T) {
+	ctx := context.Background()
+	dsn := "file:" + filepath.Join(t.TempDir(), "nanoaxm.db") + "?_pragma=busy_timeout(5000)"
+
+	// two storages on the same file to act like separate processes
+	var stores []*SQLiteStorage
+	for i := 0; i < 2; i++ {
+		s, err := New(WithDSN(dsn))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.db.Close()
+		stores = append(stores, s)
+	}
+
+	err := stores[0].StoreAuthCredentials(ctx, "test", storage.AuthCredentials{
+		ClientID:      "test-client-id",
+		KeyID:         "test-key-id",
+		PrivateKeyPEM: []byte("test-key"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls atomic.Int32
+	refresher := func(context.Context) (storage.AccessToken, error) {
+		calls.Add(1)
+		return storage.AccessToken{Token: "token", Validity: time.Hour, Expiry: time.Now().Add(time.Hour)}, nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(s *SQLiteStorage) {
+			defer wg.Done()
+			_, err := s.GetOrRefreshAccessToken(ctx, "test", refresher, nil)
+			errs <- err
+		}(stores[i%2])
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if have, want := calls.Load(), int32(1); have != want {
+		t.Errorf("refresh calls: have: %d, want: %d", have, want)
+	}
+}
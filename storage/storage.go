@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -21,7 +22,15 @@ type AuthCredentials struct {
 
 	// The private key in PEM encoded form. Provided in the AxM portal.
 	// Note Apple restricts the private key to a single download from the portal.
+	// Optional if client assertions are signed with a key kept outside
+	// of storage, e.g. by a signing agent (see client.WithSigner).
 	PrivateKeyPEM []byte
+
+	// Scope is the OAuth 2 scope, e.g. "business.api" or "school.api".
+	// Optional. If empty the scope is determined from the client ID.
+	// Must be a single scope token: multiple (space separated) scopes
+	// are not supported.
+	Scope string
 }
 
 // ValidError tests ac for common missing or invalid fields.
@@ -32,12 +41,26 @@ func (ac AuthCredentials) ValidError() error {
 	if ac.KeyID == "" {
 		return fmt.Errorf("%w: empty key ID", ErrInvalidAuthCredentials)
 	}
-	if ac.PrivateKeyPEM == nil {
-		return fmt.Errorf("%w: nil private key", ErrInvalidAuthCredentials)
+	if strings.ContainsAny(ac.Scope, " \t\r\n") {
+		return fmt.Errorf("%w: multiple scopes not supported: %q", ErrInvalidAuthCredentials, ac.Scope)
+	}
+	if ac.Scope != "" && !validScopeToken(ac.Scope) {
+		return fmt.Errorf("%w: invalid scope: %q", ErrInvalidAuthCredentials, ac.Scope)
 	}
 	return nil
 }
 
+// validScopeToken returns true if s is a single valid OAuth 2 scope token.
+// See https://datatracker.ietf.org/doc/html/rfc6749#section-3.3
+func validScopeToken(s string) bool {
+	for _, c := range s {
+		if c < 0x21 || c > 0x7e || c == '"' || c == '\\' {
+			return false
+		}
+	}
+	return s != ""
+}
+
 // Valid returns true if ac is valid.
 func (ac AuthCredentials) Valid() bool {
 	return ac.ValidError() == nil
@@ -54,9 +77,26 @@ type AuthCredentialsStorer interface {
 	// [ErrInvalidAXMName] should be returned if axmName is invalid.
 	// Implementations should be agnostic about the actual data in the private key field.
 	// This is to facilitate a potential key encryption encapsulation.
+	// Implementations should clear any stored client assertion and
+	// access token for axmName as they belong to the previous credentials.
 	StoreAuthCredentials(ctx context.Context, axmName string, ac AuthCredentials) error
 }
 
+// AuthCredentialsLister lists the AxM names with stored auth credentials.
+type AuthCredentialsLister interface {
+	// ListAXMNames returns the sorted AxM names with stored auth credentials.
+	ListAXMNames(ctx context.Context) ([]string, error)
+}
+
+// AuthCredentialsDeleter deletes stored auth credentials.
+type AuthCredentialsDeleter interface {
+	// DeleteAuthCredentials deletes the auth credentials for axmName
+	// including any stored client assertion and access token.
+	// [ErrInvalidAXMName] should be returned if axmName is invalid
+	// (including if it has no auth credentials).
+	DeleteAuthCredentials(ctx context.Context, axmName string) error
+}
+
 // ClientAssertion is a token with an expiry and validity.
 type ClientAssertion struct {
 	Token    string
@@ -64,6 +104,9 @@ type ClientAssertion struct {
 	Expiry   time.Time
 	ClientID string
 	JTI      string
+
+	// Scope is the OAuth 2 scope from the auth credentials, if any.
+	Scope string
 }
 
 var (
@@ -105,6 +148,54 @@ type ClientAssertionRefresher interface {
 	GetOrRefreshClientAssertion(ctx context.Context, axmName string, refreshFunc func(ctx context.Context, ac AuthCredentials) (ClientAssertion, error), refresh bool) (ClientAssertion, error)
 }
 
+// AccessToken is an OAuth 2 access token with an expiry and validity.
+type AccessToken struct {
+	Token    string
+	Validity time.Duration
+	Expiry   time.Time
+}
+
+// ValidError returns the specific error causing t to be invalid.
+func (t AccessToken) ValidError() error {
+	if t.Token == "" {
+		return ErrEmptyToken
+	}
+	if t.Validity <= 0 {
+		return ErrEmptyValidity
+	}
+	if t.Expiry.IsZero() {
+		return ErrExpiryZero
+	}
+	return nil
+}
+
+// Valid returns true if t is valid.
+func (t AccessToken) Valid() bool {
+	return t.ValidError() == nil
+}
+
+// Expired returns true if t has expired.
+func (t AccessToken) Expired() bool {
+	return !time.Now().Before(t.Expiry)
+}
+
+// AccessTokenRefresher is optionally implemented by storage backends
+// to share OAuth 2 access tokens between multiple NanoAXM instances.
+type AccessTokenRefresher interface {
+	// GetOrRefreshAccessToken refreshes the OAuth 2 access token for axmName and stores it.
+	// A stored token is returned if it is valid and due returns false
+	// for it; otherwise refreshFunc is called. A nil due uses
+	// [AccessToken.Expired]. A due that always returns true forces
+	// a refresh.
+	// The due check must happen under the same lock as the refresh so
+	// that it sees the token stored by any simultaneous refresh.
+	// Implementations should beware that this function may be called
+	// simultaneously and may need to implement some sort of distributed
+	// (i.e. database) locking mechanism.
+	// The refreshFunc should not itself access storage for axmName.
+	GetOrRefreshAccessToken(ctx context.Context, axmName string, refreshFunc func(ctx context.Context) (AccessToken, error), due func(AccessToken) bool) (AccessToken, error)
+}
+
 type AllStorage interface {
 	AuthCredentialsRetriever
 	AuthCredentialsStorer
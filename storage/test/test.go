@@ -11,6 +11,7 @@ import (
 	"errors"
 	"log"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 
@@ -138,4 +139,285 @@ func TestStorage(t *testing.T, ctx context.Context, s storage.AllStorage, gca Ge
 	if have, want := tok, testToken; !reflect.DeepEqual(have, want) {
 		t.Errorf("token: have: %v, want: %v", have, want)
 	}
+
+	atStore, ok := s.(storage.AccessTokenRefresher)
+	if ok {
+		testAccessTokenRefresher(t, ctx, atStore, "test-axm-name-01")
+	}
+
+	// storing auth creds again should clear the stored tokens
+	err = s.StoreAuthCredentials(ctx, "test-axm-name-01", ac)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tTok, err = gca(ctx, "test-axm-name-01")
+	if err == nil && tTok.Valid() {
+		t.Error("client assertion should have been cleared")
+	}
+
+	if ok {
+		var refreshed bool
+		_, err = atStore.GetOrRefreshAccessToken(ctx, "test-axm-name-01", func(context.Context) (storage.AccessToken, error) {
+			refreshed = true
+			return storage.AccessToken{Token: "x", Validity: time.Hour, Expiry: time.Now().Add(time.Hour)}, nil
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !refreshed {
+			t.Error("access token should have been cleared")
+		}
+	}
+
+	testScope(t, ctx, s, gca, "test-axm-name-01", ac)
+
+	testNoPrivateKey(t, ctx, s, "test-axm-name-03")
+
+	lister, ok := s.(storage.AuthCredentialsLister)
+	if ok {
+		testLister(t, ctx, lister, "test-axm-name-01")
+	}
+
+	deleter, ok := s.(storage.AuthCredentialsDeleter)
+	if ok {
+		testDeleter(t, ctx, s, deleter, "test-axm-name-02", ac)
+	}
+}
+
+func testLister(t *testing.T, ctx context.Context, s storage.AuthCredentialsLister, axmName string) {
+	names, err := s.ListAXMNames(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("names not sorted: %v", names)
+	}
+	if !containsString(names, axmName) {
+		t.Errorf("names: %v: missing: %s", names, axmName)
+	}
+}
+
+func testDeleter(t *testing.T, ctx context.Context, s storage.AllStorage, d storage.AuthCredentialsDeleter, axmName string, ac storage.AuthCredentials) {
+	err := d.DeleteAuthCredentials(ctx, axmName)
+	if have, want := err, storage.ErrInvalidAXMName; !errors.Is(have, want) {
+		t.Errorf("deleting missing name: have: %v; want: %v", have, want)
+	}
+
+	if err = s.StoreAuthCredentials(ctx, axmName, ac); err != nil {
+		t.Fatal(err)
+	}
+
+	lister, ok := s.(storage.AuthCredentialsLister)
+	if ok {
+		testLister(t, ctx, lister, axmName)
+	}
+
+	if err = d.DeleteAuthCredentials(ctx, axmName); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.RetrieveAuthCredentials(ctx, axmName)
+	if have, want := err, storage.ErrInvalidAXMName; !errors.Is(have, want) {
+		t.Errorf("retrieving deleted name: have: %v; want: %v", have, want)
+	}
+
+	if ok {
+		names, err := lister.ListAXMNames(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if containsString(names, axmName) {
+			t.Errorf("names: %v: should not contain deleted: %s", names, axmName)
+		}
+	}
+
+	// storing again after deleting should work like new
+	if err = s.StoreAuthCredentials(ctx, axmName, ac); err != nil {
+		t.Fatal(err)
+	}
+	if err = d.DeleteAuthCredentials(ctx, axmName); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testNoPrivateKey tests storing auth credentials without a private key.
+// I.e. for client assertions signed by a signing agent.
+func testNoPrivateKey(t *testing.T, ctx context.Context, s storage.AllStorage, axmName string) {
+	ac := storage.AuthCredentials{
+		ClientID: "test-client-id-03",
+		KeyID:    "test-key-id-03",
+		Scope:    client.ScopeSchool,
+	}
+	if err := s.StoreAuthCredentials(ctx, axmName, ac); err != nil {
+		t.Fatal(err)
+	}
+
+	ac2, err := s.RetrieveAuthCredentials(ctx, axmName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ac2.PrivateKeyPEM) > 0 {
+		t.Errorf("private key: have: %q, want: empty", ac2.PrivateKeyPEM)
+	}
+	// storage may return an empty (vs. nil) private key
+	ac2.PrivateKeyPEM = nil
+	if have, want := ac2, ac; !reflect.DeepEqual(have, want) {
+		t.Errorf("auth creds: have: %v; want: %v", have, want)
+	}
+}
+
+// containsString returns true if s is in ss.
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func testScope(t *testing.T, ctx context.Context, s storage.AllStorage, gca GetClientAssertion, axmName string, ac storage.AuthCredentials) {
+	ac.Scope = "business.api school.api"
+	if err := s.StoreAuthCredentials(ctx, axmName, ac); !errors.Is(err, storage.ErrInvalidAuthCredentials) {
+		t.Errorf("should have errored, multiple scopes: %v", err)
+	}
+
+	ac.Scope = client.ScopeBusiness
+	if err := s.StoreAuthCredentials(ctx, axmName, ac); err != nil {
+		t.Fatal(err)
+	}
+
+	ac2, err := s.RetrieveAuthCredentials(ctx, axmName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := ac2, ac; !reflect.DeepEqual(have, want) {
+		t.Errorf("auth creds: have: %v; want: %v", have, want)
+	}
+
+	refresher := func(ctx context.Context, ac storage.AuthCredentials) (token storage.ClientAssertion, err error) {
+		now := time.Now()
+		token.Validity = client.ClientAssertionDaysExpiry * 24 * time.Hour
+		token.Expiry = now.Add(token.Validity).Truncate(time.Second)
+		token.JTI = uuid.NewString()
+		token.ClientID = ac.ClientID
+		token.Scope = ac.Scope
+		token.Token, err = client.NewClientAssertion(ac, client.Audience, token.JTI, now, token.Expiry)
+		return
+	}
+
+	_, err = s.GetOrRefreshClientAssertion(ctx, axmName, refresher, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tok, err := gca(ctx, axmName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := tok.Scope, ac.Scope; have != want {
+		t.Errorf("client assertion scope: have: %q, want: %q", have, want)
+	}
+
+	// legacy auth creds have no explicit scope
+	ac.Scope = ""
+	if err = s.StoreAuthCredentials(ctx, axmName, ac); err != nil {
+		t.Fatal(err)
+	}
+
+	ac2, err = s.RetrieveAuthCredentials(ctx, axmName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := ac2.Scope, ""; have != want {
+		t.Errorf("auth creds scope: have: %q, want: %q", have, want)
+	}
+}
+
+func testAccessTokenRefresher(t *testing.T, ctx context.Context, s storage.AccessTokenRefresher, axmName string) {
+	_, err := s.GetOrRefreshAccessToken(ctx, axmName, nil, nil)
+	if err == nil {
+		t.Fatal("nil refresher fn must produce error but got nil")
+	}
+
+	var calls int
+	refresher := func(validity time.Duration) func(context.Context) (storage.AccessToken, error) {
+		return func(context.Context) (storage.AccessToken, error) {
+			calls++
+			return storage.AccessToken{
+				Token:    uuid.NewString(),
+				Validity: validity,
+				// truncate to second as storage backends may do that anyway
+				Expiry: time.Now().Add(validity).Truncate(time.Second),
+			}, nil
+		}
+	}
+
+	// the first retrieval should refresh as there's no token yet
+	tok, err := s.GetOrRefreshAccessToken(ctx, axmName, refresher(time.Hour), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tok.ValidError(); err != nil {
+		t.Errorf("token not valid: %v", err)
+	}
+
+	// the second retrieval should come from storage
+	tok2, err := s.GetOrRefreshAccessToken(ctx, axmName, refresher(time.Hour), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := tok2, tok; !reflect.DeepEqual(have, want) {
+		t.Errorf("access token: have: %v, want: %v", have, want)
+	}
+	if have, want := calls, 1; have != want {
+		t.Errorf("refresh calls: have: %d, want: %d", have, want)
+	}
+
+	// forcing a refresh should replace the token
+	force := func(storage.AccessToken) bool { return true }
+	_, err = s.GetOrRefreshAccessToken(ctx, axmName, refresher(-time.Hour), force)
+	if err == nil {
+		t.Error("expected error for invalid refreshed token")
+	}
+	tok2, err = s.GetOrRefreshAccessToken(ctx, axmName, refresher(time.Hour), force)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok2.Token == tok.Token {
+		t.Error("expected refreshed access token")
+	}
+	if have, want := calls, 3; have != want {
+		t.Errorf("refresh calls: have: %d, want: %d", have, want)
+	}
+
+	// a stored token that is due should be refreshed
+	var dueTok storage.AccessToken
+	due := func(token storage.AccessToken) bool {
+		dueTok = token
+		return token.Expiry.Before(time.Now().Add(2 * time.Hour))
+	}
+	tok, err = s.GetOrRefreshAccessToken(ctx, axmName, refresher(3*time.Hour), due)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := dueTok.Token, tok2.Token; have != want {
+		t.Errorf("due token: have: %q, want: %q", have, want)
+	}
+	if tok.Token == tok2.Token {
+		t.Error("expected refreshed access token")
+	}
+
+	// and a stored token that is not due should not be
+	tok2, err = s.GetOrRefreshAccessToken(ctx, axmName, refresher(3*time.Hour), due)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := tok2.Token, tok.Token; have != want {
+		t.Errorf("access token: have: %q, want: %q", have, want)
+	}
+	if have, want := calls, 4; have != want {
+		t.Errorf("refresh calls: have: %d, want: %d", have, want)
+	}
 }
@@ -5,6 +5,7 @@
 # $1 - Client ID: identifier from Apple AxM portal
 # $2 - Key ID: identifier from Apple AxM portal
 # $3 - Private key: path to file downloaded from AxM portal
+# $4 - Scope (optional): OAuth 2 scope, e.g. "business.api" or "school.api"
 
 # Required envvars:
 #
@@ -26,4 +27,5 @@ curl \
     -F "client_id=$1" \
     -F "key_id=$2" \
     -F "private_key=@$3" \
+    -F "scope=$4" \
     "$URL"